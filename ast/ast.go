@@ -0,0 +1,195 @@
+// Package ast declares the types used to represent syntax trees for ZON documents.
+//
+// Nodes keep the exact spelling of their tokens along with the whitespace and
+// comments surrounding them, so that a tree produced by Parse is printed back
+// byte for byte by Fprint, while still allowing values to be changed in place.
+package ast
+
+import "github.com/peterhellberg/zon"
+
+// Pos is a position in the source.
+type Pos struct {
+	Offset int // byte offset, starting at 0
+	Line   int // line number, starting at 1
+	Column int // byte column within the line, starting at 1
+}
+
+// Span is the source range of a node, from the start of its
+// first token to the end of its last token.
+type Span struct {
+	Start Pos
+	End   Pos
+}
+
+// Comment is a line comment.
+type Comment struct {
+	Space string // whitespace preceding the comment
+	Text  string // comment text, including the leading // but not the newline
+	Pos   Pos
+}
+
+// Trivia is the whitespace and comments preceding a token.
+type Trivia struct {
+	Comments []*Comment
+	Space    string // whitespace after the last comment
+}
+
+// Node is implemented by every node in the tree.
+type Node interface {
+	Span() Span
+	node()
+}
+
+type span Span
+
+func (s span) Span() Span { return Span(s) }
+func (span) node()        {}
+
+// File is a parsed ZON document.
+type File struct {
+	span
+	Value    Node
+	Trailing *Comment // comment on the same line as the end of Value
+	End      Trivia   // trivia before the end of the input
+}
+
+// Struct is a struct literal, .{ .name = value, ... }.
+type Struct struct {
+	span
+	Leading Trivia
+	Fields  []*Field
+	Close   Trivia // trivia before the closing brace
+}
+
+// Tuple is a tuple literal, .{ value, ... }.
+// An empty literal, .{}, is represented as a Tuple without elements.
+type Tuple struct {
+	span
+	Leading Trivia
+	Elems   []*Elem
+	Close   Trivia // trivia before the closing brace
+}
+
+// Field is a single .name = value entry of a struct literal.
+type Field struct {
+	span
+	Leading  Trivia
+	Name     string  // identifier as written, without the leading dot, such as name or @"foo-bar"
+	Equal    Trivia  // trivia before the =
+	Value    Node    // value, with its own leading trivia after the =
+	Comma    *Trivia // trivia before the trailing comma, or nil if there is none
+	Trailing *Comment
+}
+
+// Key returns the decoded field name.
+func (f *Field) Key() (string, error) {
+	return unquoteName(f.Name)
+}
+
+// Elem is a single element of a tuple literal.
+type Elem struct {
+	span
+	Value    Node
+	Comma    *Trivia // trivia before the trailing comma, or nil if there is none
+	Trailing *Comment
+}
+
+// String is a string literal.
+type String struct {
+	span
+	Leading Trivia
+	Text    string // literal as written, including quotes
+}
+
+// Value returns the decoded string.
+func (s *String) Value() (string, error) {
+	return zon.Unquote(s.Text)
+}
+
+// MultilineString is a multiline string literal made of \\ lines.
+type MultilineString struct {
+	span
+	Leading Trivia
+	Text    string // literal as written, including the whitespace between lines
+}
+
+// Value returns the decoded string.
+func (s *MultilineString) Value() (string, error) {
+	return zon.Unquote(s.Text)
+}
+
+// Number is an integer or float literal, possibly negative.
+type Number struct {
+	span
+	Leading Trivia
+	Text    string // literal as written, such as 0x99e5_365e or -1.5e3
+}
+
+// Char is a character literal.
+type Char struct {
+	span
+	Leading Trivia
+	Text    string // literal as written, including quotes
+}
+
+// EnumLiteral is an enum literal, .name or .@"name".
+type EnumLiteral struct {
+	span
+	Leading Trivia
+	Name    string // identifier as written, without the leading dot
+}
+
+// Value returns the decoded name.
+func (e *EnumLiteral) Value() (string, error) {
+	return unquoteName(e.Name)
+}
+
+// Ident is a bare identifier: true, false, null, inf or nan.
+type Ident struct {
+	span
+	Leading Trivia
+	Name    string
+}
+
+// Neg is a negated value, as in -inf.
+// Negative number literals are represented by Number alone.
+type Neg struct {
+	span
+	Leading Trivia
+	X       Node
+}
+
+func unquoteName(name string) (string, error) {
+	if len(name) > 0 && name[0] == '@' {
+		return zon.Unquote(name[1:])
+	}
+
+	return name, nil
+}
+
+// Inspect traverses the tree rooted at n in depth-first order, calling f for each node.
+// If f returns false, the children of that node are skipped.
+func Inspect(n Node, f func(Node) bool) {
+	if n == nil || !f(n) {
+		return
+	}
+
+	switch n := n.(type) {
+	case *File:
+		Inspect(n.Value, f)
+	case *Struct:
+		for _, field := range n.Fields {
+			Inspect(field, f)
+		}
+	case *Tuple:
+		for _, elem := range n.Elems {
+			Inspect(elem, f)
+		}
+	case *Field:
+		Inspect(n.Value, f)
+	case *Elem:
+		Inspect(n.Value, f)
+	case *Neg:
+		Inspect(n.X, f)
+	}
+}
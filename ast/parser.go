@@ -0,0 +1,292 @@
+package ast
+
+import (
+	"strings"
+
+	"github.com/peterhellberg/zon"
+)
+
+// Parse parses a ZON document into a syntax tree.
+func Parse(src []byte) (*File, error) {
+	p := &parser{src: src, s: zon.NewScanner(src)}
+
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	value, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+
+	f := &File{Value: value}
+
+	f.Trailing = p.trailing()
+
+	if p.tok.Kind != zon.TokenEOF {
+		return nil, p.unexpected("end of input")
+	}
+
+	f.End = p.lead
+	f.span = span(value.Span())
+
+	return f, nil
+}
+
+type parser struct {
+	src  []byte
+	s    *zon.Scanner
+	tok  zon.Token // current token
+	lead Trivia    // trivia before the current token
+	end  int       // offset just after the previous token
+}
+
+// advance moves to the next non-comment token, collecting the trivia before it.
+func (p *parser) advance() error {
+	var lead Trivia
+
+	for {
+		tok, err := p.s.Scan()
+		if err != nil {
+			return err
+		}
+
+		space := string(p.src[p.end:tok.Offset])
+
+		if tok.Kind != zon.TokenComment {
+			lead.Space = space
+
+			p.tok, p.lead = tok, lead
+
+			return nil
+		}
+
+		lead.Comments = append(lead.Comments, &Comment{Space: space, Text: tok.Text, Pos: startPos(tok)})
+
+		p.end = tok.End()
+	}
+}
+
+// next consumes the current token, returning it and its leading trivia.
+func (p *parser) next() (zon.Token, Trivia, error) {
+	tok, lead := p.tok, p.lead
+
+	p.end = tok.End()
+
+	return tok, lead, p.advance()
+}
+
+// trailing removes and returns the first comment before the current token
+// if it is on the same line as the previous token.
+func (p *parser) trailing() *Comment {
+	if len(p.lead.Comments) == 0 || strings.Contains(p.lead.Comments[0].Space, "\n") {
+		return nil
+	}
+
+	c := p.lead.Comments[0]
+
+	p.lead.Comments = p.lead.Comments[1:]
+
+	if len(p.lead.Comments) == 0 {
+		p.lead.Comments = nil
+	}
+
+	return c
+}
+
+// peek returns the kind of the non-comment token after the current one.
+func (p *parser) peek() zon.TokenKind {
+	s := *p.s
+
+	for {
+		tok, err := s.Scan()
+		if err != nil {
+			return zon.TokenEOF
+		}
+
+		if tok.Kind != zon.TokenComment {
+			return tok.Kind
+		}
+	}
+}
+
+// unexpected returns a *zon.SyntaxError reporting the current token.
+func (p *parser) unexpected(expected string) error {
+	return p.s.Unexpected(p.tok, expected)
+}
+
+func (p *parser) value() (Node, error) {
+	switch p.tok.Kind {
+	case zon.TokenLBrace:
+		return p.initList()
+	case zon.TokenMinus:
+		tok, lead, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+
+		x, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+
+		return &Neg{span: span{startPos(tok), x.Span().End}, Leading: lead, X: x}, nil
+	}
+
+	tok, lead, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	s := span{startPos(tok), endPos(tok)}
+
+	switch tok.Kind {
+	case zon.TokenString:
+		return &String{span: s, Leading: lead, Text: tok.Text}, nil
+	case zon.TokenMultilineString:
+		return &MultilineString{span: s, Leading: lead, Text: tok.Text}, nil
+	case zon.TokenNumber:
+		return &Number{span: s, Leading: lead, Text: tok.Text}, nil
+	case zon.TokenChar:
+		return &Char{span: s, Leading: lead, Text: tok.Text}, nil
+	case zon.TokenEnumLiteral:
+		return &EnumLiteral{span: s, Leading: lead, Name: tok.Text[1:]}, nil
+	case zon.TokenIdent, zon.TokenTrue, zon.TokenFalse, zon.TokenNull:
+		return &Ident{span: s, Leading: lead, Name: tok.Text}, nil
+	default:
+		p.tok = tok
+
+		return nil, p.unexpected("value")
+	}
+}
+
+// initList parses a struct or tuple literal, deciding which from its first entry.
+func (p *parser) initList() (Node, error) {
+	open, lead, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.tok.Kind == zon.TokenEnumLiteral && p.peek() == zon.TokenEqual {
+		return p.structLit(open, lead)
+	}
+
+	return p.tupleLit(open, lead)
+}
+
+func (p *parser) structLit(open zon.Token, lead Trivia) (*Struct, error) {
+	n := &Struct{Leading: lead}
+
+	for p.tok.Kind != zon.TokenRBrace {
+		if p.tok.Kind != zon.TokenEnumLiteral {
+			return nil, p.unexpected("field")
+		}
+
+		name, lead, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+
+		f := &Field{Leading: lead, Name: name.Text[1:]}
+
+		if p.tok.Kind != zon.TokenEqual {
+			return nil, p.unexpected("'='")
+		}
+
+		f.Equal = p.lead
+
+		if _, _, err := p.next(); err != nil {
+			return nil, err
+		}
+
+		if f.Value, err = p.value(); err != nil {
+			return nil, err
+		}
+
+		f.span = span{startPos(name), f.Value.Span().End}
+
+		if p.tok.Kind == zon.TokenComma {
+			comma, lead, err := p.next()
+			if err != nil {
+				return nil, err
+			}
+
+			f.Comma, f.span.End = &lead, endPos(comma)
+		} else if p.tok.Kind != zon.TokenRBrace {
+			return nil, p.unexpected("',' or '}'")
+		}
+
+		f.Trailing = p.trailing()
+
+		n.Fields = append(n.Fields, f)
+	}
+
+	n.Close = p.lead
+
+	closing, _, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	n.span = span{startPos(open), endPos(closing)}
+
+	return n, nil
+}
+
+func (p *parser) tupleLit(open zon.Token, lead Trivia) (*Tuple, error) {
+	n := &Tuple{Leading: lead}
+
+	for p.tok.Kind != zon.TokenRBrace {
+		if p.tok.Kind == zon.TokenEOF {
+			return nil, p.unexpected("value or '}'")
+		}
+
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+
+		e := &Elem{span: span(value.Span()), Value: value}
+
+		if p.tok.Kind == zon.TokenComma {
+			comma, lead, err := p.next()
+			if err != nil {
+				return nil, err
+			}
+
+			e.Comma, e.span.End = &lead, endPos(comma)
+		} else if p.tok.Kind != zon.TokenRBrace {
+			return nil, p.unexpected("',' or '}'")
+		}
+
+		e.Trailing = p.trailing()
+
+		n.Elems = append(n.Elems, e)
+	}
+
+	n.Close = p.lead
+
+	closing, _, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	n.span = span{startPos(open), endPos(closing)}
+
+	return n, nil
+}
+
+func startPos(tok zon.Token) Pos {
+	return Pos{Offset: tok.Offset, Line: tok.Line, Column: tok.Column}
+}
+
+func endPos(tok zon.Token) Pos {
+	pos := Pos{Offset: tok.End(), Line: tok.Line, Column: tok.Column + len(tok.Text)}
+
+	if i := strings.LastIndexByte(tok.Text, '\n'); i >= 0 {
+		pos.Line += strings.Count(tok.Text, "\n")
+		pos.Column = len(tok.Text) - i
+	}
+
+	return pos
+}
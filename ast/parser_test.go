@@ -0,0 +1,200 @@
+package ast
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/peterhellberg/zon"
+)
+
+func TestParseComments(t *testing.T) {
+	src, err := os.ReadFile("../testdata/comments.zon")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := Parse(src)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	s, ok := f.Value.(*Struct)
+	if !ok {
+		t.Fatalf("f.Value = %T, want *Struct", f.Value)
+	}
+
+	if len(s.Leading.Comments) != 1 || s.Leading.Comments[0].Text != "// Comment before object" {
+		t.Fatalf("s.Leading = %+v", s.Leading)
+	}
+
+	if len(s.Fields) != 4 {
+		t.Fatalf("len(s.Fields) = %d, want 4", len(s.Fields))
+	}
+
+	field := s.Fields[2]
+
+	if field.Name != "field" || field.Trailing == nil || field.Trailing.Text != "// Trailing comment" {
+		t.Fatalf("field = %+v, trailing %+v", field, field.Trailing)
+	}
+
+	if want := (Pos{Offset: 119, Line: 5, Column: 31}); field.Trailing.Pos != want {
+		t.Fatalf("field.Trailing.Pos = %+v, want %+v", field.Trailing.Pos, want)
+	}
+
+	another := s.Fields[3]
+
+	if len(another.Leading.Comments) != 1 || another.Leading.Comments[0].Text != "// Comment between fields" {
+		t.Fatalf("another.Leading = %+v", another.Leading)
+	}
+
+	if want := (Span{Start: Pos{175, 9, 5}, End: Pos{236, 11, 7}}); another.Span() != want {
+		t.Fatalf("another.Span() = %+v, want %+v", another.Span(), want)
+	}
+
+	value := another.Value.(*Struct).Fields[0].Value.(*Tuple)
+
+	if len(value.Elems) != 3 {
+		t.Fatalf("len(value.Elems) = %d, want 3", len(value.Elems))
+	}
+
+	if n, ok := value.Elems[1].Value.(*Number); !ok || n.Text != "2" {
+		t.Fatalf("value.Elems[1].Value = %#v", value.Elems[1].Value)
+	}
+
+	if name := s.Fields[0].Value.(*EnumLiteral); name.Name != "comment" {
+		t.Fatalf("name = %+v", name)
+	}
+
+	if fp := s.Fields[1].Value.(*Number); fp.Text != "0xcd164bbdb7002101" {
+		t.Fatalf("fingerprint = %+v", fp)
+	}
+}
+
+func TestParseValues(t *testing.T) {
+	src := `.{ .@"foo-bar" = "a\tb", .c = 'x', .n = -inf, .e = .@"enum val", .m = null, .t = .{}, .s =
+    \\line one
+    \\line two
+, }`
+
+	f, err := Parse([]byte(src))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	s := f.Value.(*Struct)
+
+	if key, err := s.Fields[0].Key(); err != nil || key != "foo-bar" {
+		t.Fatalf("Key() = %q, %v", key, err)
+	}
+
+	if v, err := s.Fields[0].Value.(*String).Value(); err != nil || v != "a\tb" {
+		t.Fatalf("String.Value() = %q, %v", v, err)
+	}
+
+	if c := s.Fields[1].Value.(*Char); c.Text != "'x'" {
+		t.Fatalf("Char = %+v", c)
+	}
+
+	if neg := s.Fields[2].Value.(*Neg); neg.X.(*Ident).Name != "inf" {
+		t.Fatalf("Neg = %+v", neg)
+	}
+
+	if v, err := s.Fields[3].Value.(*EnumLiteral).Value(); err != nil || v != "enum val" {
+		t.Fatalf("EnumLiteral.Value() = %q, %v", v, err)
+	}
+
+	if id := s.Fields[4].Value.(*Ident); id.Name != "null" {
+		t.Fatalf("Ident = %+v", id)
+	}
+
+	if tup := s.Fields[5].Value.(*Tuple); len(tup.Elems) != 0 {
+		t.Fatalf("Tuple = %+v", tup)
+	}
+
+	ml := s.Fields[6].Value.(*MultilineString)
+
+	if v, err := ml.Value(); err != nil || v != "line one\nline two" {
+		t.Fatalf("MultilineString.Value() = %q, %v", v, err)
+	}
+
+	if want := (Pos{Offset: len(src) - 4, Line: 3, Column: 15}); ml.Span().End != want {
+		t.Fatalf("ml.Span().End = %+v, want %+v", ml.Span().End, want)
+	}
+
+	var kinds []string
+
+	Inspect(f, func(n Node) bool {
+		switch n.(type) {
+		case *Field, *Elem:
+		default:
+			kinds = append(kinds, strings.TrimPrefix(strings.TrimPrefix(typeName(n), "*ast."), "ast."))
+		}
+
+		return true
+	})
+
+	if got := strings.Join(kinds, " "); got != "File Struct String Char Neg Ident EnumLiteral Ident Tuple MultilineString" {
+		t.Fatalf("Inspect visited %s", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tt := range []struct {
+		src  string
+		want string
+	}{
+		{``, "expected value, found end of input at line 1, column 1"},
+		{`.{ .a = 1 .b = 2 }`, "expected ',' or '}', found enum literal .b at line 1, column 11"},
+		{`.{ .a = 1, 2 }`, "expected field, found number 2 at line 1, column 12"},
+		{`.{ .a = 1, .b 2 }`, "expected '=', found number 2 at line 1, column 15"},
+		{`.{ 1,`, "expected value or '}', found end of input"},
+		{`1 2`, "expected end of input, found number 2 at line 1, column 3"},
+		{`.{ .a = "x }`, "unterminated string"},
+		{`.{ = }`, "expected value, found '='"},
+	} {
+		_, err := Parse([]byte(tt.src))
+		if err == nil {
+			t.Errorf("Parse(%q) returned no error", tt.src)
+
+			continue
+		}
+
+		var se *zon.SyntaxError
+		if !errors.As(err, &se) {
+			t.Errorf("Parse(%q) error = %T, want *zon.SyntaxError", tt.src, err)
+		}
+
+		if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Parse(%q) error = %q, want it to contain %q", tt.src, err, tt.want)
+		}
+	}
+}
+
+func typeName(n Node) string {
+	switch n.(type) {
+	case *File:
+		return "File"
+	case *Struct:
+		return "Struct"
+	case *Tuple:
+		return "Tuple"
+	case *String:
+		return "String"
+	case *MultilineString:
+		return "MultilineString"
+	case *Number:
+		return "Number"
+	case *Char:
+		return "Char"
+	case *EnumLiteral:
+		return "EnumLiteral"
+	case *Ident:
+		return "Ident"
+	case *Neg:
+		return "Neg"
+	default:
+		return "?"
+	}
+}
@@ -0,0 +1,144 @@
+package ast
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// Fprint writes the source for n to w.
+//
+// Tokens are written as spelled in the tree, each preceded by its trivia,
+// so printing an unmodified tree returned by Parse reproduces the input exactly.
+func Fprint(w io.Writer, n Node) error {
+	var p printer
+
+	if err := p.node(n); err != nil {
+		return err
+	}
+
+	_, err := w.Write(p.buf.Bytes())
+
+	return err
+}
+
+// Format returns the source for n.
+func Format(n Node) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := Fprint(&buf, n); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+type printer struct {
+	buf bytes.Buffer
+}
+
+func (p *printer) trivia(t Trivia) {
+	for _, c := range t.Comments {
+		p.comment(c)
+	}
+
+	p.buf.WriteString(t.Space)
+}
+
+func (p *printer) comment(c *Comment) {
+	if c != nil {
+		p.buf.WriteString(c.Space)
+		p.buf.WriteString(c.Text)
+	}
+}
+
+func (p *printer) comma(t *Trivia) {
+	if t != nil {
+		p.trivia(*t)
+		p.buf.WriteByte(',')
+	}
+}
+
+func (p *printer) node(n Node) error {
+	switch n := n.(type) {
+	case *File:
+		if err := p.node(n.Value); err != nil {
+			return err
+		}
+
+		p.comment(n.Trailing)
+		p.trivia(n.End)
+	case *Struct:
+		p.trivia(n.Leading)
+		p.buf.WriteString(".{")
+
+		for _, f := range n.Fields {
+			if err := p.node(f); err != nil {
+				return err
+			}
+		}
+
+		p.trivia(n.Close)
+		p.buf.WriteByte('}')
+	case *Tuple:
+		p.trivia(n.Leading)
+		p.buf.WriteString(".{")
+
+		for _, e := range n.Elems {
+			if err := p.node(e); err != nil {
+				return err
+			}
+		}
+
+		p.trivia(n.Close)
+		p.buf.WriteByte('}')
+	case *Field:
+		p.trivia(n.Leading)
+		p.buf.WriteByte('.')
+		p.buf.WriteString(n.Name)
+		p.trivia(n.Equal)
+		p.buf.WriteByte('=')
+
+		if err := p.node(n.Value); err != nil {
+			return err
+		}
+
+		p.comma(n.Comma)
+		p.comment(n.Trailing)
+	case *Elem:
+		if err := p.node(n.Value); err != nil {
+			return err
+		}
+
+		p.comma(n.Comma)
+		p.comment(n.Trailing)
+	case *String:
+		p.trivia(n.Leading)
+		p.buf.WriteString(n.Text)
+	case *MultilineString:
+		p.trivia(n.Leading)
+		p.buf.WriteString(n.Text)
+	case *Number:
+		p.trivia(n.Leading)
+		p.buf.WriteString(n.Text)
+	case *Char:
+		p.trivia(n.Leading)
+		p.buf.WriteString(n.Text)
+	case *EnumLiteral:
+		p.trivia(n.Leading)
+		p.buf.WriteByte('.')
+		p.buf.WriteString(n.Name)
+	case *Ident:
+		p.trivia(n.Leading)
+		p.buf.WriteString(n.Name)
+	case *Neg:
+		p.trivia(n.Leading)
+		p.buf.WriteByte('-')
+
+		return p.node(n.X)
+	default:
+		return fmt.Errorf("zon/ast: unexpected node type %T", n)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package ast
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestFprintRoundTrip(t *testing.T) {
+	for _, name := range []string{
+		"../testdata/build.zig.zon",
+		"../testdata/comments.zon",
+		"../testdata/example.zon",
+	} {
+		t.Run(name, func(t *testing.T) {
+			src, err := os.ReadFile(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assertRoundTrip(t, string(src))
+		})
+	}
+}
+
+func TestFprintRoundTripEdgeCases(t *testing.T) {
+	for _, src := range []string{
+		`.{}`,
+		"  .{ }  \n",
+		"// only a comment before\n42 // and after\n// and below\n",
+		".{1,2,3}",
+		".{ .a = 1 // no comma\n}",
+		".{\n    // leading\n    .a = -inf, // trailing\n    // dangling\n}\n",
+		".{ .s =\n    \\\\one\n    \\\\two\n  , .c = '\\n' }",
+		".{ .@\"foo-bar\" = .@\"x y\" , .n = - inf }",
+		".{ .a\n  = // between\n  1, }",
+		"\r\n.{\r\n\t.a = 1,\r\n}\r\n",
+	} {
+		assertRoundTrip(t, src)
+	}
+}
+
+func TestFprintModified(t *testing.T) {
+	src, err := os.ReadFile("../testdata/comments.zon")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := Parse(src)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	s := f.Value.(*Struct)
+
+	s.Fields[1].Value.(*Number).Text = "0x1234_5678"
+	s.Fields[2].Trailing.Text = "// Changed comment"
+
+	got, err := Format(f)
+	if err != nil {
+		t.Fatalf("Format returned error: %v", err)
+	}
+
+	want := strings.Replace(string(src), "0xcd164bbdb7002101", "0x1234_5678", 1)
+	want = strings.Replace(want, "// Trailing comment", "// Changed comment", 1)
+
+	if string(got) != want {
+		t.Fatalf("Format = %s\nwant %s", got, want)
+	}
+}
+
+func assertRoundTrip(t *testing.T, src string) {
+	t.Helper()
+
+	f, err := Parse([]byte(src))
+	if err != nil {
+		t.Fatalf("Parse(%q) returned error: %v", src, err)
+	}
+
+	var b strings.Builder
+
+	if err := Fprint(&b, f); err != nil {
+		t.Fatalf("Fprint returned error: %v", err)
+	}
+
+	if got := b.String(); got != src {
+		t.Fatalf("Fprint = %q, want %q", got, src)
+	}
+}
@@ -0,0 +1,163 @@
+package zon
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type benchDependency struct {
+	URL     string   `zon:"url"`
+	Hash    string   `zon:"hash"`
+	Lazy    bool     `zon:"lazy,omitempty"`
+	Path    string   `zon:"path,omitempty"`
+	Version string   `zon:"version"`
+	Tags    []string `zon:"tags"`
+}
+
+type benchConfig struct {
+	Name              string                     `zon:"name"`
+	Version           string                     `zon:"version"`
+	Fingerprint       uint64                     `zon:"fingerprint"`
+	MinimumZigVersion string                     `zon:"minimum_zig_version"`
+	Description       string                     `zon:"description"`
+	License           string                     `zon:"license"`
+	Homepage          string                     `zon:"homepage"`
+	Repository        string                     `zon:"repository"`
+	Authors           []string                   `zon:"authors"`
+	Keywords          []string                   `zon:"keywords"`
+	Paths             []string                   `zon:"paths"`
+	Port              int                        `zon:"port"`
+	Timeout           float64                    `zon:"timeout"`
+	Debug             bool                       `zon:"debug"`
+	Verbose           bool                       `zon:"verbose"`
+	Workers           int                        `zon:"workers"`
+	Dependencies      map[string]benchDependency `zon:"dependencies"`
+	Targets           []benchDependency          `zon:"targets"`
+}
+
+func newBenchConfig(n int) benchConfig {
+	c := benchConfig{
+		Name:              "bench",
+		Version:           "1.2.3",
+		Fingerprint:       0x99e5365e8f803dab,
+		MinimumZigVersion: "0.16.0",
+		Description:       "A generated configuration used for benchmarks",
+		License:           "MIT",
+		Homepage:          "https://example.com",
+		Repository:        "https://example.com/bench.git",
+		Authors:           []string{"a", "b", "c"},
+		Keywords:          []string{"zon", "zig", "config"},
+		Paths:             []string{"build.zig", "build.zig.zon", "src"},
+		Port:              8080,
+		Timeout:           1.5,
+		Workers:           16,
+		Dependencies:      map[string]benchDependency{},
+	}
+
+	for i := range n {
+		d := benchDependency{
+			URL:     fmt.Sprintf("https://example.com/dep%d.tar.gz", i),
+			Hash:    fmt.Sprintf("1220%060d", i),
+			Version: "0.1.0",
+			Tags:    []string{"x", "y"},
+		}
+
+		c.Dependencies[fmt.Sprintf("dep%d", i)] = d
+		c.Targets = append(c.Targets, d)
+	}
+
+	return c
+}
+
+func benchData(b *testing.B, n int) []byte {
+	data, err := Marshal(newBenchConfig(n))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return data
+}
+
+// benchUnknownData returns a config where every dependency also has fields
+// that are not part of benchDependency.
+func benchUnknownData(b *testing.B, n int) []byte {
+	data := string(benchData(b, n))
+
+	return []byte(strings.ReplaceAll(data, `.version = "0.1.0",`,
+		`.version = "0.1.0", .extra = .{ .a = 1, .b = .{ "x", "y" } }, .note = "unknown",`))
+}
+
+func BenchmarkMarshal(b *testing.B) {
+	c := newBenchConfig(100)
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if _, err := Marshal(c); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshal(b *testing.B) {
+	data := benchData(b, 100)
+
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	for b.Loop() {
+		var c benchConfig
+
+		if err := Unmarshal(data, &c); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalUnknownFields(b *testing.B) {
+	data := benchUnknownData(b, 100)
+
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	for b.Loop() {
+		var c benchConfig
+
+		if err := Unmarshal(data, &c); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalAny(b *testing.B) {
+	data := benchData(b, 100)
+
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	for b.Loop() {
+		var v any
+
+		if err := Unmarshal(data, &v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalParallel(b *testing.B) {
+	data := benchData(b, 100)
+
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			var c benchConfig
+
+			if err := Unmarshal(data, &c); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/peterhellberg/zon"
 )
@@ -24,15 +25,30 @@ func run(r io.Reader, w io.Writer) error {
 	flag.Parse()
 
 	if *j {
-		dec := zon.NewDecoder(r)
-		enc := json.NewEncoder(w)
+		return toJSON(r, w, *i)
+	}
+
+	return toZON(r, w, *i)
+}
 
-		enc.SetIndent("", *i)
+func toJSON(r io.Reader, w io.Writer, indent string) error {
+	dec := zon.NewDecoder(r)
+	enc := json.NewEncoder(w)
 
-		return convert(dec, enc)
-	}
+	enc.SetIndent("", indent)
+
+	return convert(dec, enc)
+}
+
+func toZON(r io.Reader, w io.Writer, indent string) error {
+	dec := json.NewDecoder(r)
 
-	return convert(json.NewDecoder(r), zon.NewEncoder(w, zon.Indent(*i)))
+	// Numbers are kept as written, so that large integers such as fingerprints stay exact.
+	dec.UseNumber()
+
+	enc := zon.NewEncoder(w, zon.Indent(indent))
+
+	return convert(literals{dec}, enc)
 }
 
 type Decoder interface{ Decode(v any) error }
@@ -47,3 +63,58 @@ func convert(dec Decoder, enc Encoder) error {
 
 	return enc.Encode(v)
 }
+
+// literals is a Decoder that turns the json.Number values of a JSON decoder
+// into zon.Number values, which encode as the number literal they hold.
+// JSON has no enum literals, so strings such as ".name", written by zon -j
+// for enum literals, are turned into zon.EnumLiteral values.
+type literals struct{ Decoder }
+
+func (l literals) Decode(v any) error {
+	if err := l.Decoder.Decode(v); err != nil {
+		return err
+	}
+
+	if p, ok := v.(*any); ok {
+		*p = zonLiterals(*p)
+	}
+
+	return nil
+}
+
+func zonLiterals(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		return zon.Number(v)
+	case string:
+		if e, ok := enumLiteral(v); ok {
+			return e
+		}
+	case map[string]any:
+		for k, e := range v {
+			v[k] = zonLiterals(e)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = zonLiterals(e)
+		}
+	}
+
+	return v
+}
+
+// enumLiteral returns the enum literal s, if s is written exactly as
+// zon.EnumLiteral writes it, such as .name or .@"two words".
+func enumLiteral(s string) (zon.EnumLiteral, bool) {
+	if !strings.HasPrefix(s, ".") {
+		return "", false
+	}
+
+	var e zon.EnumLiteral
+
+	if err := zon.Unmarshal([]byte(s), &e, zon.Strict(true)); err != nil || e.String() != s {
+		return "", false
+	}
+
+	return e, true
+}
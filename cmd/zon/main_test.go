@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/peterhellberg/zon"
+)
+
+func TestRoundTrip(t *testing.T) {
+	for _, name := range []string{"build.zig.zon", "comments.zon", "example.zon"} {
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile("../../testdata/" + name)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var js, out bytes.Buffer
+
+			if err := toJSON(bytes.NewReader(data), &js, "  "); err != nil {
+				t.Fatalf("toJSON returned error: %v", err)
+			}
+
+			if err := toZON(&js, &out, "    "); err != nil {
+				t.Fatalf("toZON returned error: %v", err)
+			}
+
+			var want, got any
+
+			if err := zon.Unmarshal(data, &want); err != nil {
+				t.Fatalf("Unmarshal of input returned error: %v", err)
+			}
+
+			if err := zon.Unmarshal(out.Bytes(), &got, zon.Strict(true)); err != nil {
+				t.Fatalf("Unmarshal of output returned error: %v\n%s", err, out.String())
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("round trip =\n%#v\nwant\n%#v", got, want)
+			}
+		})
+	}
+}
+
+func TestToZONNumbers(t *testing.T) {
+	var out bytes.Buffer
+
+	in := `{"fingerprint": 11089329437232086443, "big": 123456789012345678901234567890, "f": 1.5e+300, "n": -0.25}`
+
+	if err := toZON(strings.NewReader(in), &out, ""); err != nil {
+		t.Fatalf("toZON returned error: %v", err)
+	}
+
+	want := ".{ .big = 123456789012345678901234567890, .f = 1.5e+300, .fingerprint = 11089329437232086443, .n = -0.25, }\n"
+
+	if got := out.String(); got != want {
+		t.Fatalf("toZON = %q, want %q", got, want)
+	}
+}
+
+func TestToZONStrings(t *testing.T) {
+	var out bytes.Buffer
+
+	in := `{"a":"0xdead","b":".debug","c":".@\"two words\"","d":".not a literal","e":"."}`
+
+	if err := toZON(strings.NewReader(in), &out, ""); err != nil {
+		t.Fatalf("toZON returned error: %v", err)
+	}
+
+	want := `.{ .a = "0xdead", .b = .debug, .c = .@"two words", .d = ".not a literal", .e = ".", }` + "\n"
+
+	if got := out.String(); got != want {
+		t.Fatalf("toZON = %q, want %q", got, want)
+	}
+
+	var js bytes.Buffer
+
+	if err := toJSON(&out, &js, ""); err != nil {
+		t.Fatalf("toJSON returned error: %v", err)
+	}
+
+	if got := strings.TrimSpace(js.String()); got != in {
+		t.Fatalf("toJSON = %s, want %s", got, in)
+	}
+}
@@ -2,27 +2,55 @@ package zon
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 )
 
 type Decoder struct {
 	r io.Reader
+	o UnmarshalOptions
 }
 
-func Decode(r io.Reader, v any) error {
-	return NewDecoder(r).Decode(v)
+func Decode(r io.Reader, v any, opts ...DecodeOption) error {
+	return NewDecoder(r, opts...).Decode(v)
 }
 
-func NewDecoder(r io.Reader) *Decoder {
-	return &Decoder{r: r}
+func NewDecoder(r io.Reader, opts ...DecodeOption) *Decoder {
+	return &Decoder{r: r, o: unmarshalOptions(opts)}
+}
+
+// UseNumber makes the Decoder decode numbers in any values as a Number.
+func (d *Decoder) UseNumber() {
+	d.o.UseNumber = true
+}
+
+// DisallowUnknownFields makes the Decoder return an error for fields
+// that do not match any field of the struct they are decoded into.
+func (d *Decoder) DisallowUnknownFields() {
+	d.o.DisallowUnknownFields = true
+}
+
+// Strict makes the Decoder reject input that std.zon.parse rejects.
+func (d *Decoder) Strict() {
+	d.o.Strict = true
 }
 
 func (d *Decoder) Decode(v any) error {
 	buf := new(bytes.Buffer)
 
-	if _, err := buf.ReadFrom(d.r); err != nil {
+	r := d.r
+
+	if d.o.MaxBytes > 0 {
+		r = io.LimitReader(r, int64(d.o.MaxBytes)+1)
+	}
+
+	if _, err := buf.ReadFrom(r); err != nil {
 		return err
 	}
 
-	return Unmarshal(buf.Bytes(), v)
+	if d.o.MaxBytes > 0 && buf.Len() > d.o.MaxBytes {
+		return fmt.Errorf("zon: input exceeds the maximum of %d bytes: %w", d.o.MaxBytes, ErrLimitExceeded)
+	}
+
+	return d.o.Unmarshal(buf.Bytes(), v)
 }
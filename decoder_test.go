@@ -2,6 +2,8 @@ package zon
 
 import (
 	"bytes"
+	"errors"
+	"strings"
 	"testing"
 )
 
@@ -20,3 +22,56 @@ func TestDecoder(t *testing.T) {
 		t.Errorf("Decoder.Decode did not set correct value, got %+v", v)
 	}
 }
+
+func TestDecoderStrict(t *testing.T) {
+	var v []int
+
+	if err := NewDecoder(strings.NewReader(".{ 1, 2 } 3"), Strict(true)).Decode(&v); !errors.Is(err, ErrUnexpectedToken) {
+		t.Fatalf("Decode returned %v, want ErrUnexpectedToken", err)
+	}
+
+	if err := Decode(strings.NewReader(".{ 1, 2 } 3"), &v); err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+}
+
+func TestDecoderSetters(t *testing.T) {
+	dec := NewDecoder(strings.NewReader(`.{ .a = 1, .b = 2 }`))
+
+	dec.UseNumber()
+	dec.DisallowUnknownFields()
+	dec.Strict()
+
+	var v map[string]any
+
+	if err := dec.Decode(&v); err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+
+	if v["a"] != Number("1") {
+		t.Fatalf("v = %#v, want Number values", v)
+	}
+
+	var s struct {
+		A int `zon:"a"`
+	}
+
+	dec = NewDecoder(strings.NewReader(`.{ .a = 1, .b = 2 }`))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&s); !errors.Is(err, ErrUnknownField) {
+		t.Fatalf("err = %v, want ErrUnknownField", err)
+	}
+}
+
+func TestDecoderMaxBytes(t *testing.T) {
+	var v []int
+
+	if err := NewDecoder(strings.NewReader(".{ 1, 2, 3 }"), MaxBytes(8)).Decode(&v); !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("err = %v, want ErrLimitExceeded", err)
+	}
+
+	if err := NewDecoder(strings.NewReader(".{ 1, 2, 3 }"), MaxBytes(12)).Decode(&v); err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package zon
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// EnumLiteral is a ZON enum literal such as .name, holding the name without the leading dot.
+//
+// Enum literals decode into EnumLiteral values, also when decoding into any,
+// and an EnumLiteral always encodes as an enum literal.
+type EnumLiteral string
+
+var enumLiteralType = reflect.TypeFor[EnumLiteral]()
+
+// String returns the enum literal as written in ZON, such as .name or .@"two words".
+func (e EnumLiteral) String() string {
+	return "." + formatIdent(string(e))
+}
+
+// MarshalText returns the enum literal as written in ZON,
+// so that it keeps its leading dot when encoded as JSON.
+func (e EnumLiteral) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// enumType holds the names of the values of a type registered with RegisterEnum.
+type enumType struct {
+	names  map[any]string           // name by value
+	values map[string]reflect.Value // value by name
+	valid  string                   // sorted list of the names as enum literals, for errors
+}
+
+var enums registry[*enumType]
+
+// RegisterEnum makes values of type T encode as the enum literals in names, such as .debug,
+// and decode from them. It is meant for Go types that model Zig enums, like
+//
+//	type Mode int
+//
+//	const (
+//		Debug Mode = iota
+//		ReleaseFast
+//	)
+//
+//	zon.RegisterEnum(map[Mode]string{Debug: "debug", ReleaseFast: "release_fast"})
+//
+// Encoding a value that is not in names and decoding an unknown name are errors.
+// Registering a type again replaces its names.
+// RegisterEnum panics if two values have the same name or a name is empty.
+func RegisterEnum[T comparable](names map[T]string) {
+	e := &enumType{
+		names:  make(map[any]string, len(names)),
+		values: make(map[string]reflect.Value, len(names)),
+	}
+
+	literals := make([]string, 0, len(names))
+
+	for v, name := range names {
+		if name == "" {
+			panic(fmt.Sprintf("zon: RegisterEnum: empty name for %v", v))
+		}
+
+		if _, ok := e.values[name]; ok {
+			panic(fmt.Sprintf("zon: RegisterEnum: duplicate name %q", name))
+		}
+
+		e.names[v] = name
+		e.values[name] = reflect.ValueOf(v)
+
+		literals = append(literals, EnumLiteral(name).String())
+	}
+
+	slices.Sort(literals)
+
+	e.valid = strings.Join(literals, ", ")
+
+	enums.store(reflect.TypeFor[T](), e)
+}
+
+// lookupEnum returns the registered enum type t, or nil if t is not registered.
+func lookupEnum(t reflect.Type) *enumType {
+	e, _ := enums.load(t)
+
+	return e
+}
+
+// name returns the name of the enum value v.
+func (e *enumType) name(v reflect.Value) (string, error) {
+	name, ok := e.names[v.Interface()]
+	if !ok {
+		return "", fmt.Errorf("zon: %v is not a value of enum %s, expected one of %s", v, v.Type(), e.valid)
+	}
+
+	return name, nil
+}
@@ -0,0 +1,188 @@
+package zon
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestEnumLiteral(t *testing.T) {
+	for _, tt := range []struct {
+		in   EnumLiteral
+		want string
+	}{
+		{"name", ".name"},
+		{"two words", `.@"two words"`},
+		{"if", `.@"if"`},
+	} {
+		if got := tt.in.String(); got != tt.want {
+			t.Errorf("EnumLiteral(%q).String() = %q, want %q", string(tt.in), got, tt.want)
+		}
+
+		data, err := Marshal(tt.in)
+		if err != nil {
+			t.Fatalf("Marshal returned error: %v", err)
+		}
+
+		if got := string(data); got != tt.want+"\n" {
+			t.Errorf("Marshal(EnumLiteral(%q)) = %q, want %q", string(tt.in), got, tt.want+"\n")
+		}
+
+		var e EnumLiteral
+
+		if err := Unmarshal(data, &e, Strict(true)); err != nil || e != tt.in {
+			t.Errorf("Unmarshal(%q) = %q, %v, want %q", data, string(e), err, string(tt.in))
+		}
+	}
+
+	var e EnumLiteral
+
+	if err := Unmarshal([]byte(`"name"`), &e); err == nil {
+		t.Fatal("Unmarshal of string into EnumLiteral returned no error")
+	}
+
+	data, err := json.Marshal(map[string]any{"kind": EnumLiteral("lib")})
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+
+	if got, want := string(data), `{"kind":".lib"}`; got != want {
+		t.Fatalf("json.Marshal = %s, want %s", got, want)
+	}
+}
+
+func TestEnumLiteralStrings(t *testing.T) {
+	type T struct {
+		Kind  EnumLiteral `zon:"kind"`
+		Name  string      `zon:"name"`
+		Other any         `zon:"other"`
+	}
+
+	in := []byte(`.{ .kind = .lib, .name = .@"zon", .other = .exe }`)
+
+	var v T
+
+	if err := Unmarshal(in, &v); err == nil {
+		t.Fatal("Unmarshal of enum literal into string returned no error")
+	}
+
+	v = T{}
+
+	if err := Unmarshal(in, &v, EnumLiteralStrings(true)); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	want := T{Kind: "lib", Name: ".zon", Other: ".exe"}
+
+	if !reflect.DeepEqual(v, want) {
+		t.Fatalf("v = %#v, want %#v", v, want)
+	}
+}
+
+type testMode int
+
+const (
+	testDebug testMode = iota
+	testReleaseFast
+	testReleaseSmall
+)
+
+func init() {
+	RegisterEnum(map[testMode]string{
+		testDebug:        "debug",
+		testReleaseFast:  "release_fast",
+		testReleaseSmall: "ReleaseSmall",
+	})
+}
+
+func TestRegisterEnum(t *testing.T) {
+	type T struct {
+		Mode  testMode            `zon:"mode"`
+		Modes []testMode          `zon:"modes"`
+		Ptr   *testMode           `zon:"ptr"`
+		Flags map[testMode]string `zon:"flags"`
+	}
+
+	fast := testReleaseFast
+
+	in := T{
+		Mode:  testDebug,
+		Modes: []testMode{testReleaseSmall, testDebug},
+		Ptr:   &fast,
+		Flags: map[testMode]string{testReleaseFast: "-O3", testDebug: "-g"},
+	}
+
+	data, err := Marshal(in, Indent(""))
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	want := `.{ .mode = .debug, .modes = .{ .ReleaseSmall, .debug, }, .ptr = .release_fast, .flags = .{ .debug = "-g", .release_fast = "-O3", }, }` + "\n"
+
+	if got := string(data); got != want {
+		t.Fatalf("Marshal = %s, want %s", got, want)
+	}
+
+	var out T
+
+	if err := Unmarshal(data, &out, Strict(true)); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(out, in) {
+		t.Fatalf("out = %+v, want %+v", out, in)
+	}
+
+	if _, err := Marshal(testMode(7)); err == nil {
+		t.Fatal("Marshal of unnamed enum value returned no error")
+	}
+}
+
+func TestRegisterEnumErrors(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want string
+	}{
+		{
+			`.{ .mode = .relase_fast }`,
+			"zon: unknown value .relase_fast for enum zon.testMode, expected one of .ReleaseSmall, .debug, .release_fast at line 1, column 12",
+		},
+		{
+			`.{ .mode = 1 }`,
+			"zon: cannot unmarshal number 1 into Go struct field Mode of type zon.testMode at .mode, line 1, column 12",
+		},
+		{
+			`.{ .flags = .{ .fast = "" } }`,
+			"zon: unknown value .fast for enum zon.testMode, expected one of .ReleaseSmall, .debug, .release_fast at line 1, column 16",
+		},
+	} {
+		var v struct {
+			Mode  testMode            `zon:"mode"`
+			Flags map[testMode]string `zon:"flags"`
+		}
+
+		err := Unmarshal([]byte(tt.in), &v)
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("Unmarshal(%s) error = %v, want %s", tt.in, err, tt.want)
+		}
+	}
+
+	var m testMode
+
+	if err := Unmarshal([]byte(`.nope`), &m); !errors.Is(err, ErrUnknownEnumValue) {
+		t.Fatalf("Unmarshal error = %v, want ErrUnknownEnumValue", err)
+	}
+}
+
+func TestRegisterEnumPanics(t *testing.T) {
+	type dup int
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("RegisterEnum with duplicate names did not panic")
+		}
+	}()
+
+	RegisterEnum(map[dup]string{1: "same", 2: "same"})
+}
@@ -0,0 +1,343 @@
+package zon
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrUnexpectedEOF is reported when the input ends in the middle of a value.
+	ErrUnexpectedEOF = errors.New("unexpected end of input")
+
+	// ErrUnexpectedToken is reported when a token is not valid where it appears.
+	ErrUnexpectedToken = errors.New("unexpected token")
+
+	// ErrInvalidCharacter is reported for bytes that cannot start a token.
+	ErrInvalidCharacter = errors.New("invalid character")
+
+	// ErrInvalidString is reported for malformed string and character literals,
+	// including bad escape sequences.
+	ErrInvalidString = errors.New("invalid string literal")
+
+	// ErrInvalidNumber is reported for malformed number literals.
+	ErrInvalidNumber = errors.New("invalid number literal")
+
+	// ErrOverflow is reported when a number does not fit in the target type.
+	ErrOverflow = errors.New("number out of range")
+
+	// ErrDuplicateField is reported in strict mode when a field name appears twice in a struct.
+	ErrDuplicateField = errors.New("duplicate field")
+
+	// ErrUnknownField is reported when DisallowUnknownFields is set and a field has no matching struct field.
+	ErrUnknownField = errors.New("unknown field")
+
+	// ErrLimitExceeded is reported when the input exceeds the MaxBytes or MaxDepth limit.
+	ErrLimitExceeded = errors.New("limit exceeded")
+
+	// ErrMissingField is reported when a field tagged required is missing from a struct literal.
+	ErrMissingField = errors.New("missing required field")
+
+	// ErrUnknownEnumValue is reported when an enum literal is not a name of the enum registered with RegisterEnum.
+	ErrUnknownEnumValue = errors.New("unknown enum value")
+
+	// ErrUnknownVariant is reported when a tag is not a variant of the union registered with RegisterUnion.
+	ErrUnknownVariant = errors.New("unknown union variant")
+)
+
+// SyntaxError describes a problem with the ZON input at a specific position.
+type SyntaxError struct {
+	Offset   int    // byte offset, starting at 0
+	Line     int    // line number, starting at 1, or 0 if unknown
+	Column   int    // byte column within the line, starting at 1, or 0 if unknown
+	Expected string // what the parser expected, if known
+	Found    string // what was found instead, if known
+	Msg      string // description of the problem, if not described by Expected and Found
+	Err      error  // one of the sentinel errors, such as ErrUnexpectedEOF
+
+	line string // the source line containing the error, for Snippet
+}
+
+func (e *SyntaxError) Error() string {
+	msg := e.Msg
+
+	if msg == "" {
+		switch {
+		case e.Expected != "" && e.Found != "":
+			msg = "expected " + e.Expected + ", found " + e.Found
+		case e.Expected != "":
+			msg = "expected " + e.Expected
+		case e.Err != nil:
+			msg = e.Err.Error()
+		default:
+			msg = "syntax error"
+		}
+	}
+
+	if e.Line == 0 {
+		return fmt.Sprintf("zon: %s at offset %d", msg, e.Offset)
+	}
+
+	return fmt.Sprintf("zon: %s at line %d, column %d", msg, e.Line, e.Column)
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
+
+// Snippet renders the source line containing the error,
+// followed by a line with a caret pointing at the offending column.
+func (e *SyntaxError) Snippet() string {
+	if e.Line == 0 {
+		return ""
+	}
+
+	prefix := strconv.Itoa(e.Line) + " | "
+
+	var b strings.Builder
+
+	b.WriteString(prefix)
+	b.WriteString(e.line)
+	b.WriteByte('\n')
+	b.WriteString(strings.Repeat(" ", len(prefix)-2))
+	b.WriteString("| ")
+
+	for i := 0; i < e.Column-1 && i < len(e.line); i++ {
+		if e.line[i] == '\t' {
+			b.WriteByte('\t')
+		} else {
+			b.WriteByte(' ')
+		}
+	}
+
+	b.WriteByte('^')
+
+	return b.String()
+}
+
+// locate fills in the line, column and source line of e from data.
+func (e *SyntaxError) locate(data []byte) *SyntaxError {
+	e.Line, e.Column, e.line = position(data, e.Offset)
+
+	return e
+}
+
+// position returns the line and column of the byte offset in data,
+// along with the text of that line.
+func position(data []byte, offset int) (line, column int, text string) {
+	offset = min(offset, len(data))
+
+	start := bytes.LastIndexByte(data[:offset], '\n') + 1
+
+	end := bytes.IndexByte(data[offset:], '\n')
+	if end < 0 {
+		end = len(data)
+	} else {
+		end += offset
+	}
+
+	line = bytes.Count(data[:start], []byte{'\n'}) + 1
+	column = offset - start + 1
+	text = strings.TrimSuffix(string(data[start:end]), "\r")
+
+	return line, column, text
+}
+
+// UnmarshalTypeError describes a ZON value that cannot be stored in a Go value of a specific type.
+type UnmarshalTypeError struct {
+	Value  string       // kind of ZON value found, such as "string" or "struct"
+	Type   reflect.Type // type of the Go value it could not be stored in
+	Path   string       // ZON path of the value, such as .dependencies.foo.hash, or empty for the root
+	Struct string       // name of the Go struct type containing the field, if any
+	Field  string       // name of the Go struct field, if any
+	Offset int          // byte offset of the value, starting at 0
+	Line   int          // line number, starting at 1
+	Column int          // byte column within the line, starting at 1
+}
+
+func (e *UnmarshalTypeError) Error() string {
+	var b strings.Builder
+
+	b.WriteString("zon: cannot unmarshal " + e.Value + " into Go ")
+
+	switch {
+	case e.Field != "" && e.Struct != "":
+		b.WriteString("struct field " + e.Struct + "." + e.Field)
+	case e.Field != "":
+		b.WriteString("struct field " + e.Field)
+	default:
+		b.WriteString("value")
+	}
+
+	b.WriteString(" of type " + e.Type.String())
+
+	b.WriteString(" at ")
+
+	if e.Path != "" {
+		b.WriteString(e.Path + ", ")
+	}
+
+	fmt.Fprintf(&b, "line %d, column %d", e.Line, e.Column)
+
+	return b.String()
+}
+
+// UnknownField is a field in the input that has no matching struct field.
+type UnknownField struct {
+	Path       string // ZON path of the field, such as .dependencies.foo.hsah
+	Struct     string // name of the Go struct type it was decoded into
+	Suggestion string // closest known field name, or empty if none is close
+	Offset     int    // byte offset of the field name, starting at 0
+	Line       int    // line number, starting at 1
+	Column     int    // byte column within the line, starting at 1
+}
+
+func (f UnknownField) String() string {
+	s := fmt.Sprintf("%s at line %d, column %d", f.Path, f.Line, f.Column)
+
+	if f.Suggestion != "" {
+		s += " (did you mean ." + formatIdent(f.Suggestion) + "?)"
+	}
+
+	return s
+}
+
+// UnknownFieldsError lists every unknown field found when DisallowUnknownFields is set.
+type UnknownFieldsError struct {
+	Fields []UnknownField
+}
+
+func (e *UnknownFieldsError) Error() string {
+	if len(e.Fields) == 1 {
+		return "zon: unknown field " + e.Fields[0].String()
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "zon: %d unknown fields:", len(e.Fields))
+
+	for _, f := range e.Fields {
+		b.WriteString("\n\t" + f.String())
+	}
+
+	return b.String()
+}
+
+func (e *UnknownFieldsError) Unwrap() error {
+	return ErrUnknownField
+}
+
+// MissingField is a required field that is missing from a struct literal.
+type MissingField struct {
+	Name   string // ZON name of the field
+	Path   string // ZON path of the struct literal, or empty for the root
+	Struct string // name of the Go struct type the literal was decoded into
+	Offset int    // byte offset of the struct literal, starting at 0
+	Line   int    // line number, starting at 1
+	Column int    // byte column within the line, starting at 1
+}
+
+func (f MissingField) String() string {
+	s := "." + formatIdent(f.Name)
+
+	if f.Path != "" {
+		s += " in " + f.Path
+	}
+
+	return s + fmt.Sprintf(" at line %d, column %d", f.Line, f.Column)
+}
+
+// MissingFieldsError lists every required field that is missing from the input.
+type MissingFieldsError struct {
+	Fields []MissingField
+}
+
+func (e *MissingFieldsError) Error() string {
+	if len(e.Fields) == 1 {
+		return "zon: missing required field " + e.Fields[0].String()
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "zon: %d missing required fields:", len(e.Fields))
+
+	for _, f := range e.Fields {
+		b.WriteString("\n\t" + f.String())
+	}
+
+	return b.String()
+}
+
+func (e *MissingFieldsError) Unwrap() error {
+	return ErrMissingField
+}
+
+// syntaxError returns a SyntaxError at pos that is not yet located.
+func syntaxError(pos int, err error, format string, args ...any) *SyntaxError {
+	return &SyntaxError{Offset: pos, Msg: fmt.Sprintf(format, args...), Err: err}
+}
+
+// describe returns a short description of tok for use in error messages.
+func describe(tok Token) string {
+	switch tok.Kind {
+	case TokenEOF, TokenLBrace, TokenRBrace, TokenEqual, TokenComma, TokenMinus, TokenTrue, TokenFalse, TokenNull:
+		return tok.Kind.String()
+	}
+
+	text := tok.Text
+
+	if i := strings.IndexByte(text, '\n'); i >= 0 {
+		text = text[:i] + "…"
+	}
+
+	if len(text) > 32 {
+		text = strings.ToValidUTF8(text[:32], "") + "…"
+	}
+
+	return tok.Kind.String() + " " + text
+}
+
+// suggest returns the name closest to key by edit distance,
+// or an empty string if none of them is close enough to be a likely typo.
+func suggest(key string, names []string) string {
+	best, bestDist := "", max(2, len(key)/3)+1
+
+	for _, name := range names {
+		if d := editDistance(key, name); d < bestDist {
+			best, bestDist = name, d
+		}
+	}
+
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
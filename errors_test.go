@@ -0,0 +1,204 @@
+package zon
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestSyntaxError(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		src     string
+		err     error
+		msg     string
+		line    int
+		column  int
+		snippet string
+	}{
+		{"unexpected eof", ".{ .a = 1,", ErrUnexpectedEOF,
+			"zon: expected '}', found end of input at line 1, column 11", 1, 11,
+			"1 | .{ .a = 1,\n  |           ^"},
+		{"unexpected token", ".{\n\t.a = 1,\n\t.b = }", ErrUnexpectedToken,
+			"zon: expected integer, found '}' at line 3, column 7", 3, 7,
+			"3 | \t.b = }\n  | \t     ^"},
+		{"invalid character", ".{ .a = # }", ErrInvalidCharacter,
+			"zon: unexpected character '#' at line 1, column 9", 1, 9,
+			"1 | .{ .a = # }\n  |         ^"},
+		{"invalid string", ".{\n    .a = \"\\q\",\n}", ErrInvalidString,
+			"zon: invalid escape sequence at line 2, column 11", 2, 11,
+			"2 |     .a = \"\\q\",\n  |           ^"},
+		{"invalid number", ".{ .a = 0x }", ErrInvalidNumber,
+			"", 1, 9,
+			"1 | .{ .a = 0x }\n  |         ^"},
+		{"overflow", ".{ .a = 300 }", ErrOverflow,
+			"zon: integer literal 300 overflows uint8 in .a at line 1, column 9", 1, 9,
+			"1 | .{ .a = 300 }\n  |         ^"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var v struct {
+				A uint8 `zon:"a"`
+				B int   `zon:"b"`
+			}
+
+			err := Unmarshal([]byte(tt.src), &v)
+
+			var se *SyntaxError
+			if !errors.As(err, &se) {
+				t.Fatalf("err = %v (%T), want *SyntaxError", err, err)
+			}
+
+			if !errors.Is(err, tt.err) {
+				t.Errorf("errors.Is(%v, %v) = false", err, tt.err)
+			}
+
+			if tt.msg != "" && se.Error() != tt.msg {
+				t.Errorf("Error() = %q, want %q", se.Error(), tt.msg)
+			}
+
+			if se.Line != tt.line || se.Column != tt.column {
+				t.Errorf("position = %d:%d, want %d:%d", se.Line, se.Column, tt.line, tt.column)
+			}
+
+			if got := se.Snippet(); got != tt.snippet {
+				t.Errorf("Snippet() =\n%s\nwant\n%s", got, tt.snippet)
+			}
+		})
+	}
+}
+
+func TestSyntaxErrorWithoutPosition(t *testing.T) {
+	err := syntaxError(4, ErrInvalidString, "unterminated string")
+
+	if got, want := err.Error(), "zon: unterminated string at offset 4"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	if got := err.Snippet(); got != "" {
+		t.Errorf("Snippet() = %q, want empty", got)
+	}
+}
+
+func TestUnmarshalTypeError(t *testing.T) {
+	type Dependency struct {
+		URL  string `zon:"url"`
+		Hash string `zon:"hash"`
+	}
+
+	type Manifest struct {
+		Name         string                `zon:"name"`
+		Version      int                   `zon:"version"`
+		Paths        []string              `zon:"paths"`
+		Dependencies map[string]Dependency `zon:"dependencies"`
+		Extra        fmt.Stringer          `zon:"extra"`
+	}
+
+	for _, tt := range []struct {
+		name string
+		src  string
+		want UnmarshalTypeError
+		msg  string
+	}{
+		{"nested field", ".{\n    .dependencies = .{\n        .foo = .{ .url = \"u\", .hash = 12 },\n    },\n}",
+			UnmarshalTypeError{Value: "number 12", Type: reflect.TypeFor[string](), Path: ".dependencies.foo.hash", Struct: "Dependency", Field: "Hash", Offset: 64, Line: 3, Column: 39},
+			"zon: cannot unmarshal number 12 into Go struct field Dependency.Hash of type string at .dependencies.foo.hash, line 3, column 39"},
+		{"slice element", `.{ .paths = .{ "a", true } }`,
+			UnmarshalTypeError{Value: "boolean", Type: reflect.TypeFor[string](), Path: ".paths[1]", Struct: "Manifest", Field: "Paths", Offset: 20, Line: 1, Column: 21},
+			""},
+		{"struct for integer", `.{ .version = .{ .major = 1 } }`,
+			UnmarshalTypeError{Value: "struct", Type: reflect.TypeFor[int](), Path: ".version", Struct: "Manifest", Field: "Version", Offset: 14, Line: 1, Column: 15},
+			""},
+		{"float for integer", `.{ .version = 1.5 }`,
+			UnmarshalTypeError{Value: "number 1.5", Type: reflect.TypeFor[int](), Path: ".version", Struct: "Manifest", Field: "Version", Offset: 14, Line: 1, Column: 15},
+			""},
+		{"string for map", `.{ .dependencies = "none" }`,
+			UnmarshalTypeError{Value: "string", Type: reflect.TypeFor[map[string]Dependency](), Path: ".dependencies", Struct: "Manifest", Field: "Dependencies", Offset: 19, Line: 1, Column: 20},
+			""},
+		{"quoted key", `.{ .dependencies = .{ .@"foo bar" = .{ .url = .x } } }`,
+			UnmarshalTypeError{Value: "enum literal", Type: reflect.TypeFor[string](), Path: `.dependencies.@"foo bar".url`, Struct: "Dependency", Field: "URL", Offset: 46, Line: 1, Column: 47},
+			""},
+		{"interface", `.{ .extra = .{ 1, 2 } }`,
+			UnmarshalTypeError{Value: "tuple", Type: reflect.TypeFor[fmt.Stringer](), Path: ".extra", Struct: "Manifest", Field: "Extra", Offset: 12, Line: 1, Column: 13},
+			""},
+		{"root", `"manifest"`,
+			UnmarshalTypeError{Value: "string", Type: reflect.TypeFor[Manifest](), Offset: 0, Line: 1, Column: 1},
+			"zon: cannot unmarshal string into Go value of type zon.Manifest at line 1, column 1"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var m Manifest
+
+			err := Unmarshal([]byte(tt.src), &m)
+
+			var ute *UnmarshalTypeError
+			if !errors.As(err, &ute) {
+				t.Fatalf("err = %v (%T), want *UnmarshalTypeError", err, err)
+			}
+
+			if *ute != tt.want {
+				t.Errorf("err = %+v, want %+v", *ute, tt.want)
+			}
+
+			if tt.msg != "" && ute.Error() != tt.msg {
+				t.Errorf("Error() = %q, want %q", ute.Error(), tt.msg)
+			}
+		})
+	}
+}
+
+func TestUnknownFieldsError(t *testing.T) {
+	one := &UnknownFieldsError{Fields: []UnknownField{
+		{Path: ".minimim_zig_version", Suggestion: "minimum_zig_version", Line: 3, Column: 5},
+	}}
+
+	if got, want := one.Error(), "zon: unknown field .minimim_zig_version at line 3, column 5 (did you mean .minimum_zig_version?)"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	two := &UnknownFieldsError{Fields: []UnknownField{
+		{Path: ".a", Line: 1, Column: 4},
+		{Path: `.b.@"c d"`, Suggestion: "c e", Line: 2, Column: 8},
+	}}
+
+	if got, want := two.Error(), "zon: 2 unknown fields:\n\t.a at line 1, column 4\n\t.b.@\"c d\" at line 2, column 8 (did you mean .@\"c e\"?)"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	names := []string{"name", "version", "minimum_zig_version", "dependencies", "paths"}
+
+	for _, tt := range []struct {
+		key  string
+		want string
+	}{
+		{"nmae", "name"},
+		{"verison", "version"},
+		{"minimim_zig_version", "minimum_zig_version"},
+		{"dependecies", "dependencies"},
+		{"path", "paths"},
+		{"url", ""},
+		{"fingerprint", ""},
+	} {
+		if got := suggest(tt.key, names); got != tt.want {
+			t.Errorf("suggest(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestMissingFieldsError(t *testing.T) {
+	one := &MissingFieldsError{Fields: []MissingField{{Name: "version", Line: 1, Column: 1}}}
+
+	if got, want := one.Error(), "zon: missing required field .version at line 1, column 1"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	two := &MissingFieldsError{Fields: []MissingField{
+		{Name: "hash", Path: ".dependencies.foo", Line: 4, Column: 16},
+		{Name: "min version", Line: 1, Column: 1},
+	}}
+
+	if got, want := two.Error(), "zon: 2 missing required fields:\n\t.hash in .dependencies.foo at line 4, column 16\n\t.@\"min version\" at line 1, column 1"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,321 @@
+package zon
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// field is a struct field that is encoded and decoded as a ZON field.
+type field struct {
+	name      string // ZON field name
+	goName    string // name of the Go struct field
+	index     []int  // index sequence for reflect.Value.FieldByIndex
+	tagged    bool   // name comes from the zon tag
+	omitEmpty bool   // omitted when encoding an empty value
+	omitZero  bool   // omitted when encoding a zero value
+	quoted    bool   // numbers and bools are encoded as strings
+	rest      bool   // collects the fields that match no other field
+	required  bool   // decoding fails if the field is missing
+
+	def        string // ZON literal for the value of a missing field
+	hasDefault bool
+
+	prefix string      // encoded name and '=' written before the value, set by planFor
+	encode encoderFunc // encoder for the type of the field, set by planFor
+	decode decoderFunc // decoder for the type of the field once pointers are followed, set by planFor
+}
+
+// typeFields returns the fields of the struct type t in the order they are encoded.
+//
+// Unexported fields and fields tagged zon:"-" are left out.
+// The name of a field is the first element of its zon tag, or the Go field name.
+//
+// Fields of embedded structs without a tag name are promoted, following the rules
+// of encoding/json: among fields with the same name the shallowest one wins,
+// then the one with a tag name, and names that remain ambiguous are dropped.
+func typeFields(t reflect.Type) []field {
+	type embedded struct {
+		typ   reflect.Type
+		index []int
+	}
+
+	var (
+		fields    []field
+		next      = []embedded{{typ: t}}
+		count     = map[reflect.Type]int{}
+		nextCount = map[reflect.Type]int{t: 1}
+		visited   = map[reflect.Type]bool{}
+	)
+
+	for len(next) > 0 {
+		current := next
+
+		next, count, nextCount = nil, nextCount, map[reflect.Type]int{}
+
+		for _, e := range current {
+			if visited[e.typ] {
+				continue
+			}
+
+			visited[e.typ] = true
+
+			for i := 0; i < e.typ.NumField(); i++ {
+				sf := e.typ.Field(i)
+
+				ft := sf.Type
+
+				if ft.Name() == "" && ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+
+				if sf.Anonymous {
+					if !sf.IsExported() && ft.Kind() != reflect.Struct {
+						continue
+					}
+				} else if !sf.IsExported() {
+					continue
+				}
+
+				tag := sf.Tag.Get("zon")
+
+				if tag == "-" {
+					continue
+				}
+
+				name, opts := parseTag(tag)
+
+				index := append(slices.Clone(e.index), i)
+
+				if name == "" && sf.Anonymous && ft.Kind() == reflect.Struct {
+					nextCount[ft]++
+
+					if nextCount[ft] == 1 {
+						next = append(next, embedded{typ: ft, index: index})
+					}
+
+					continue
+				}
+
+				f := field{
+					name:      name,
+					goName:    sf.Name,
+					index:     index,
+					tagged:    name != "",
+					omitEmpty: opts.contains("omitempty"),
+					omitZero:  opts.contains("omitzero"),
+					quoted:    opts.contains("string") && canQuote(sf.Type),
+					rest:      opts.contains("rest") && canRest(sf.Type),
+					required:  opts.contains("required"),
+				}
+
+				f.def, f.hasDefault = opts.defaultValue()
+
+				if f.name == "" {
+					f.name = sf.Name
+				}
+
+				fields = append(fields, f)
+
+				// A struct embedded more than once at the same depth makes its fields ambiguous,
+				// so the field is added twice to be dropped below.
+				if count[e.typ] > 1 {
+					fields = append(fields, f)
+				}
+			}
+		}
+	}
+
+	slices.SortStableFunc(fields, func(a, b field) int {
+		return cmp.Or(
+			strings.Compare(a.name, b.name),
+			cmp.Compare(len(a.index), len(b.index)),
+			compareBool(b.tagged, a.tagged),
+			slices.Compare(a.index, b.index),
+		)
+	})
+
+	out := fields[:0]
+
+	for i := 0; i < len(fields); {
+		j := i + 1
+
+		for j < len(fields) && fields[j].name == fields[i].name {
+			j++
+		}
+
+		if f, ok := dominantField(fields[i:j]); ok {
+			out = append(out, f)
+		}
+
+		i = j
+	}
+
+	slices.SortFunc(out, func(a, b field) int {
+		return slices.Compare(a.index, b.index)
+	})
+
+	return out
+}
+
+// dominantField returns the field that wins among fields with the same name,
+// which are sorted by depth and then tagged fields first.
+func dominantField(fields []field) (field, bool) {
+	if len(fields) > 1 && len(fields[0].index) == len(fields[1].index) && fields[0].tagged == fields[1].tagged {
+		return field{}, false
+	}
+
+	return fields[0], true
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// fieldForEncode returns the field of the struct v at index,
+// or false if it is inside an embedded struct pointer that is nil.
+func fieldForEncode(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(x)
+	}
+
+	return v, true
+}
+
+// fieldForDecode returns the field of the struct v at index,
+// allocating embedded struct pointers that are nil.
+func fieldForDecode(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("zon: cannot set embedded pointer to unexported struct %s", v.Type().Elem())
+				}
+
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(x)
+	}
+
+	return v, nil
+}
+
+// tagOptions is the comma-separated list of options after the name in a zon tag.
+// A default= option takes the rest of the tag, so that its value may contain commas.
+type tagOptions string
+
+// parseTag splits a zon struct tag into its name and options.
+func parseTag(tag string) (string, tagOptions) {
+	name, opts, _ := strings.Cut(tag, ",")
+
+	return strings.TrimSpace(name), tagOptions(opts)
+}
+
+// contains reports whether the option name is in the list.
+func (o tagOptions) contains(name string) bool {
+	for s := string(o); s != ""; {
+		var opt string
+
+		opt, s, _ = strings.Cut(s, ",")
+
+		opt = strings.TrimSpace(opt)
+
+		if opt == name {
+			return true
+		}
+
+		if strings.HasPrefix(opt, "default=") {
+			return false
+		}
+	}
+
+	return false
+}
+
+// defaultValue returns the ZON literal of the default= option, if there is one.
+func (o tagOptions) defaultValue() (string, bool) {
+	for s := string(o); s != ""; {
+		opt := strings.TrimLeft(s, " ")
+
+		if value, ok := strings.CutPrefix(opt, "default="); ok {
+			return strings.TrimSpace(value), true
+		}
+
+		_, s, _ = strings.Cut(s, ",")
+	}
+
+	return "", false
+}
+
+// canQuote reports whether the string tag option applies to values of type t.
+func canQuote(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// canRest reports whether the rest tag option applies to values of type t,
+// which must be a RawValue or a map with string keys.
+func canRest(t reflect.Type) bool {
+	return t == rawValueType || t.Kind() == reflect.Map && t.Key().Kind() == reflect.String
+}
+
+type isZeroer interface {
+	IsZero() bool
+}
+
+var isZeroerType = reflect.TypeFor[isZeroer]()
+
+// isZero reports whether v is the zero value for the omitzero option,
+// using its IsZero method if it has one.
+func isZero(v reflect.Value) bool {
+	t := v.Type()
+
+	switch {
+	case !v.CanInterface():
+		return v.IsZero()
+	case t.Implements(isZeroerType):
+		if (t.Kind() == reflect.Pointer || t.Kind() == reflect.Interface) && v.IsNil() {
+			return true
+		}
+
+		return v.Interface().(isZeroer).IsZero()
+	case reflect.PointerTo(t).Implements(isZeroerType):
+		if !v.CanAddr() {
+			c := reflect.New(t).Elem()
+			c.Set(v)
+			v = c
+		}
+
+		return v.Addr().Interface().(isZeroer).IsZero()
+	default:
+		return v.IsZero()
+	}
+}
@@ -0,0 +1,167 @@
+package zon
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseTag(t *testing.T) {
+	for _, tt := range []struct {
+		tag  string
+		name string
+		opts []string
+	}{
+		{"", "", nil},
+		{"name", "name", nil},
+		{"omit,omitempty", "omit", []string{"omitempty"}},
+		{",omitzero,string", "", []string{"omitzero", "string"}},
+		{" spaced , omitempty ", "spaced", []string{"omitempty"}},
+	} {
+		name, opts := parseTag(tt.tag)
+
+		if name != tt.name {
+			t.Errorf("parseTag(%q) name = %q, want %q", tt.tag, name, tt.name)
+		}
+
+		for _, opt := range tt.opts {
+			if !opts.contains(opt) {
+				t.Errorf("parseTag(%q) options do not contain %q", tt.tag, opt)
+			}
+		}
+
+		if opts.contains("omit") {
+			t.Errorf("parseTag(%q) options contain the name", tt.tag)
+		}
+	}
+}
+
+func TestTypeFields(t *testing.T) {
+	type T struct {
+		A       int     `zon:"a"`
+		B       string  `zon:",omitempty"`
+		Skipped bool    `zon:"-"`
+		Dash    bool    `zon:"-,"`
+		N       float64 `zon:"n,string"`
+		S       string  `zon:"s,string"`
+		Z       int     `zon:"z,omitzero"`
+		private int
+	}
+
+	want := []field{
+		{name: "a", goName: "A", index: []int{0}, tagged: true},
+		{name: "B", goName: "B", index: []int{1}, omitEmpty: true},
+		{name: "-", goName: "Dash", index: []int{3}, tagged: true},
+		{name: "n", goName: "N", index: []int{4}, tagged: true, quoted: true},
+		{name: "s", goName: "S", index: []int{5}, tagged: true},
+		{name: "z", goName: "Z", index: []int{6}, tagged: true, omitZero: true},
+	}
+
+	if got := typeFields(reflect.TypeFor[T]()); !reflect.DeepEqual(got, want) {
+		t.Fatalf("typeFields =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestTypeFieldsEmbedded(t *testing.T) {
+	type Base struct {
+		ID   int    `zon:"id"`
+		Name string `zon:"name"`
+		Kind string
+	}
+
+	type Meta struct {
+		Name    string
+		Version int `zon:"version"`
+		Kind    string
+	}
+
+	type inner struct {
+		Hidden string `zon:"hidden"`
+	}
+
+	type Named struct {
+		Value int `zon:"value"`
+	}
+
+	type T struct {
+		Base
+		*Meta
+		inner
+		Named   `zon:"named"`
+		Version string `zon:"version"`
+	}
+
+	want := []field{
+		{name: "id", goName: "ID", index: []int{0, 0}, tagged: true},
+		{name: "name", goName: "Name", index: []int{0, 1}, tagged: true},
+		{name: "Name", goName: "Name", index: []int{1, 0}},
+		{name: "hidden", goName: "Hidden", index: []int{2, 0}, tagged: true},
+		{name: "named", goName: "Named", index: []int{3}, tagged: true},
+		{name: "version", goName: "Version", index: []int{4}, tagged: true},
+	}
+
+	if got := typeFields(reflect.TypeFor[T]()); !reflect.DeepEqual(got, want) {
+		t.Fatalf("typeFields =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+type zeroer struct{ n int }
+
+func (z zeroer) IsZero() bool { return z.n < 0 }
+
+type ptrZeroer struct{ n int }
+
+func (z *ptrZeroer) IsZero() bool { return z.n == 1 }
+
+func TestIsZero(t *testing.T) {
+	for _, tt := range []struct {
+		v    any
+		want bool
+	}{
+		{0, true},
+		{1, false},
+		{[]int{}, false},
+		{time.Time{}, true},
+		{zeroer{n: 0}, false},
+		{zeroer{n: -1}, true},
+		{ptrZeroer{n: 1}, true},
+		{ptrZeroer{n: 0}, false},
+		{(*zeroer)(nil), true},
+	} {
+		if got := isZero(reflect.ValueOf(tt.v)); got != tt.want {
+			t.Errorf("isZero(%#v) = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestTagDefaultValue(t *testing.T) {
+	for _, tt := range []struct {
+		tag  string
+		def  string
+		ok   bool
+		opts []string
+	}{
+		{"port,default=8080", "8080", true, nil},
+		{"list,omitempty,default=.{ 1, 2 }", ".{ 1, 2 }", true, []string{"omitempty"}},
+		{`name,default="a,required"`, `"a,required"`, true, nil},
+		{",required", "", false, []string{"required"}},
+		{"", "", false, nil},
+	} {
+		_, opts := parseTag(tt.tag)
+
+		def, ok := opts.defaultValue()
+		if def != tt.def || ok != tt.ok {
+			t.Errorf("parseTag(%q) default = %q, %v, want %q, %v", tt.tag, def, ok, tt.def, tt.ok)
+		}
+
+		for _, opt := range tt.opts {
+			if !opts.contains(opt) {
+				t.Errorf("parseTag(%q) options do not contain %q", tt.tag, opt)
+			}
+		}
+
+		if tt.ok && opts.contains("required") {
+			t.Errorf("parseTag(%q) options contain required from the default value", tt.tag)
+		}
+	}
+}
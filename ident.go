@@ -0,0 +1,59 @@
+package zon
+
+// keywords are the Zig keywords that must be quoted when used as identifiers.
+var keywords = map[string]bool{
+	"addrspace": true, "align": true, "allowzero": true, "and": true,
+	"anyframe": true, "anytype": true, "asm": true, "async": true,
+	"await": true, "break": true, "callconv": true, "catch": true,
+	"comptime": true, "const": true, "continue": true, "defer": true,
+	"else": true, "enum": true, "errdefer": true, "error": true,
+	"export": true, "extern": true, "fn": true, "for": true,
+	"if": true, "inline": true, "linksection": true, "noalias": true,
+	"noinline": true, "nosuspend": true, "opaque": true, "or": true,
+	"orelse": true, "packed": true, "pub": true, "resume": true,
+	"return": true, "struct": true, "suspend": true, "switch": true,
+	"test": true, "threadlocal": true, "try": true, "union": true,
+	"unreachable": true, "usingnamespace": true, "var": true,
+	"volatile": true, "while": true,
+}
+
+// isIdent reports whether s can be written as a bare Zig identifier.
+func isIdent(s string) bool {
+	if s == "" || isDigit(s[0]) {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; !isLetter(c) && !isDigit(c) && c != '_' {
+			return false
+		}
+	}
+
+	return !keywords[s]
+}
+
+// formatIdent returns name as a Zig identifier,
+// using the @"..." form when it is not a valid bare identifier.
+func formatIdent(name string) string {
+	if isIdent(name) {
+		return name
+	}
+
+	return "@" + quote(name)
+}
+
+// scanName decodes the identifier starting at data[pos], which is either a bare
+// identifier or a quoted @"..." identifier, returning it and the position just after it.
+func scanName(data []byte, pos int) (string, int, error) {
+	if hasPrefixAt(data, pos, `@"`) {
+		return scanString(data, pos+1)
+	}
+
+	end := scanIdent(data, pos)
+
+	if end == pos || isDigit(data[pos]) {
+		return "", pos, &SyntaxError{Offset: pos, Expected: "identifier", Err: ErrUnexpectedToken}
+	}
+
+	return string(data[pos:end]), end, nil
+}
@@ -0,0 +1,107 @@
+package zon
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFormatIdent(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want string
+	}{
+		{"name", "name"},
+		{"_private", "_private"},
+		{"snake_case_2", "snake_case_2"},
+		{"u8", "u8"},
+		{"foo-bar", `@"foo-bar"`},
+		{"1st", `@"1st"`},
+		{"with space", `@"with space"`},
+		{"if", `@"if"`},
+		{"error", `@"error"`},
+		{"", `@""`},
+		{`quo"te`, `@"quo\"te"`},
+	} {
+		if got := formatIdent(tt.in); got != tt.want {
+			t.Errorf("formatIdent(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestScanName(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want string
+		rest string
+	}{
+		{"name = 1", "name", " = 1"},
+		{`@"foo-bar" = 1`, "foo-bar", " = 1"},
+		{`@"esc\x41ped"`, "escAped", ""},
+	} {
+		got, end, err := scanName([]byte(tt.in), 0)
+		if err != nil {
+			t.Fatalf("scanName(%q) returned error: %v", tt.in, err)
+		}
+
+		if got != tt.want || tt.in[end:] != tt.rest {
+			t.Errorf("scanName(%q) = %q, rest %q, want %q, rest %q", tt.in, got, tt.in[end:], tt.want, tt.rest)
+		}
+	}
+
+	for _, in := range []string{"", "= 1", "1abc", `@"unterminated`, "@name"} {
+		if _, _, err := scanName([]byte(in), 0); err == nil {
+			t.Errorf("scanName(%q) returned no error", in)
+		}
+	}
+}
+
+func TestQuotedIdentRoundTrip(t *testing.T) {
+	type Dep struct {
+		URL string `zon:"url"`
+	}
+
+	v := struct {
+		Deps  map[string]Dep `zon:"dependencies"`
+		Error string         `zon:"error"`
+		Kebab int            `zon:"kebab-case"`
+	}{
+		Deps:  map[string]Dep{"zig-clap": {URL: "https://example.com"}},
+		Error: ".not-an-ident",
+		Kebab: 1,
+	}
+
+	data, err := Marshal(v, Indent(""))
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	want := `.{ .dependencies = .{ .@"zig-clap" = .{ .url = "https://example.com", }, }, .@"error" = ".not-an-ident", .@"kebab-case" = 1, }` + "\n"
+
+	if got := string(data); got != want {
+		t.Fatalf("Marshal = %s, want %s", got, want)
+	}
+
+	var got struct {
+		Deps  map[string]Dep `zon:"dependencies"`
+		Error string         `zon:"error"`
+		Kebab int            `zon:"kebab-case"`
+	}
+
+	if err := Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, v) {
+		t.Fatalf("round trip = %+v, want %+v", got, v)
+	}
+
+	var m map[string]any
+
+	if err := Unmarshal([]byte(`.{ .@"foo bar" = .@"enum value", .plain = .@"if" }`), &m); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if m["foo bar"] != EnumLiteral("enum value") || m["plain"] != EnumLiteral("if") {
+		t.Fatalf("m = %#v", m)
+	}
+}
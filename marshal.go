@@ -2,12 +2,22 @@ package zon
 
 import (
 	"bytes"
+	"cmp"
+	"encoding"
 	"fmt"
+	"math/big"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
+// Marshal returns the ZON encoding of v.
+//
+// Integers, including big.Int values, are written in decimal, so the radix of a literal
+// such as 0xff is not kept when it is decoded and encoded again. Fields that must keep
+// the spelling of their literal can use Number or RawValue instead.
 func Marshal(v any, opts ...Option) ([]byte, error) {
 	var b bytes.Buffer
 
@@ -21,167 +31,521 @@ func Marshal(v any, opts ...Option) ([]byte, error) {
 		return nil, err
 	}
 
-	_ = b.WriteByte('\n')
+	if !bytes.HasSuffix(b.Bytes(), []byte{'\n'}) {
+		_ = b.WriteByte('\n')
+	}
 
 	return b.Bytes(), nil
 }
 
 func marshal(v reflect.Value, b *bytes.Buffer, o Options, l int) error {
-	w, wb, n := b.WriteString, b.WriteByte, "\n"
+	if !v.IsValid() {
+		b.WriteString("null")
 
-	if o.Indent == "" {
-		n = " "
+		return nil
 	}
 
-	if !v.IsValid() {
-		w("null")
-		return nil
+	return encoderFor(v.Type())(v, b, o, l)
+}
+
+// newEncoder resolves the encoder for values of type t.
+// The hooks of t are checked first, in the order Marshal documents.
+func newEncoder(t reflect.Type) encoderFunc {
+	enc := typeEncoder(t)
+
+	if h := hooksFor(t); t.Kind() != reflect.Interface && (h.marshaler || h.ptrMarshaler) {
+		return func(v reflect.Value, b *bytes.Buffer, o Options, l int) error {
+			if m, ok := marshaler(v); ok {
+				return marshalCustom(m, t, b, o, l)
+			}
+
+			return enc(v, b, o, l)
+		}
 	}
 
-	switch v.Kind() {
+	return enc
+}
+
+// typeEncoder resolves the encoder for t, leaving out Marshaler.
+func typeEncoder(t reflect.Type) encoderFunc {
+	switch t {
+	case bigIntType:
+		return encodeBigInt
+	case bigFloatType:
+		return encodeBigFloat
+	case rawValueType:
+		return marshalRaw
+	case enumLiteralType:
+		return encodeEnumLiteral
+	case numberType:
+		return encodeNumber
+	}
+
+	if e := lookupEnum(t); e != nil {
+		return func(v reflect.Value, b *bytes.Buffer, o Options, l int) error {
+			name, err := e.name(v)
+			if err != nil {
+				return err
+			}
+
+			b.WriteString(EnumLiteral(name).String())
+
+			return nil
+		}
+	}
+
+	enc := kindEncoder(t)
+
+	if h := hooksFor(t); t.Kind() != reflect.Interface && t.Kind() != reflect.Pointer && (h.textMarshaler || h.ptrTextMarshaler) {
+		return func(v reflect.Value, b *bytes.Buffer, o Options, l int) error {
+			if m, ok := textMarshaler(v); ok {
+				return marshalText(m, t, b)
+			}
+
+			return enc(v, b, o, l)
+		}
+	}
+
+	return enc
+}
+
+// kindEncoder resolves the encoder for the kind of t.
+func kindEncoder(t reflect.Type) encoderFunc {
+	switch t.Kind() {
 	case reflect.Bool:
-		w(strconv.FormatBool(v.Bool()))
+		return encodeBool
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		w(strconv.FormatInt(v.Int(), 10))
+		return encodeInt
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-		w(strconv.FormatUint(v.Uint(), 10))
+		return encodeUint
 	case reflect.Float32, reflect.Float64:
-		w(strconv.FormatFloat(v.Float(), 'g', -1, 64))
+		return encodeFloat
 	case reflect.String:
-		s := v.String()
-
-		if isDotLiteral(s) || isHexLiteral(s) {
-			w(s)
-		} else {
-			wb('"')
-			w(s)
-			wb('"')
-		}
+		return encodeString
 	case reflect.Slice, reflect.Array:
-		w(".{" + n)
+		return encodeSlice
+	case reflect.Map:
+		return encodeMap
+	case reflect.Struct:
+		return encodeStruct
+	case reflect.Pointer, reflect.Interface:
+		if u := lookupUnion(t); u != nil {
+			return func(v reflect.Value, b *bytes.Buffer, o Options, l int) error {
+				if v.IsNil() {
+					b.WriteString("null")
 
-		for i := 0; i < v.Len(); i++ {
-			writeIndent(b, o, l+1)
+					return nil
+				}
 
-			if err := marshal(v.Index(i), b, o, l+1); err != nil {
-				return err
+				return marshalUnion(v, u, b, o, l)
 			}
+		}
 
-			w("," + n)
+		return encodeElem
+	default:
+		return func(v reflect.Value, b *bytes.Buffer, o Options, l int) error {
+			return fmt.Errorf("zon: unsupported type %s", t)
 		}
+	}
+}
 
-		writeIndent(b, o, l)
+func encodeBigInt(v reflect.Value, b *bytes.Buffer, o Options, l int) error {
+	n := v.Interface().(big.Int)
 
-		wb('}')
-	case reflect.Map:
-		w(".{" + n)
+	b.WriteString(n.String())
 
-		keys := v.MapKeys()
+	return nil
+}
 
-		for i, k := range keys {
-			writeIndent(b, o, l+1)
+func encodeBigFloat(v reflect.Value, b *bytes.Buffer, o Options, l int) error {
+	f := v.Interface().(big.Float)
 
-			if k.Kind() == reflect.String {
-				s := k.String()
-				if !strings.HasPrefix(s, ".") {
-					wb('.')
-				}
+	b.WriteString(formatBigFloat(&f))
+
+	return nil
+}
+
+func encodeEnumLiteral(v reflect.Value, b *bytes.Buffer, o Options, l int) error {
+	b.WriteString(EnumLiteral(v.String()).String())
+
+	return nil
+}
+
+func encodeNumber(v reflect.Value, b *bytes.Buffer, o Options, l int) error {
+	n := Number(v.String())
+
+	if n == "" {
+		n = "0"
+	}
+
+	if !n.valid() {
+		return fmt.Errorf("zon: invalid number literal %q", v.String())
+	}
+
+	b.WriteString(n.String())
+
+	return nil
+}
+
+func encodeBool(v reflect.Value, b *bytes.Buffer, o Options, l int) error {
+	b.WriteString(strconv.FormatBool(v.Bool()))
+
+	return nil
+}
+
+func encodeInt(v reflect.Value, b *bytes.Buffer, o Options, l int) error {
+	if o.Runes && v.Kind() == reflect.Int32 && utf8.ValidRune(rune(v.Int())) {
+		b.WriteString(quoteRune(rune(v.Int())))
+	} else {
+		b.WriteString(strconv.FormatInt(v.Int(), 10))
+	}
+
+	return nil
+}
+
+func encodeUint(v reflect.Value, b *bytes.Buffer, o Options, l int) error {
+	b.WriteString(strconv.FormatUint(v.Uint(), 10))
+
+	return nil
+}
+
+func encodeFloat(v reflect.Value, b *bytes.Buffer, o Options, l int) error {
+	b.WriteString(formatFloat(v.Float(), v.Type().Bits()))
+
+	return nil
+}
+
+func encodeString(v reflect.Value, b *bytes.Buffer, o Options, l int) error {
+	w, wb := b.WriteString, b.WriteByte
+
+	s := v.String()
+
+	if o.LegacyLiterals && isDotLiteral(s) {
+		wb('.')
+		w(formatIdent(s[1:]))
+	} else if o.LegacyLiterals && isHexLiteral(s) {
+		w(s)
+	} else if o.Multiline && canWriteMultiline(s) {
+		writeMultiline(b, o, s, l)
+	} else {
+		w(quote(s))
+	}
+
+	return nil
+}
+
+func encodeSlice(v reflect.Value, b *bytes.Buffer, o Options, l int) error {
+	w, wb, n := b.WriteString, b.WriteByte, newline(o)
+
+	enc := encoderFor(v.Type().Elem())
+
+	w(".{" + n)
+
+	for i := 0; i < v.Len(); i++ {
+		writeIndent(b, o, l+1)
+
+		if err := enc(v.Index(i), b, o, l+1); err != nil {
+			return err
+		}
+
+		w("," + n)
+	}
+
+	writeIndent(b, o, l)
+
+	wb('}')
+
+	return nil
+}
+
+func encodeMap(v reflect.Value, b *bytes.Buffer, o Options, l int) error {
+	w, wb, n := b.WriteString, b.WriteByte, newline(o)
+
+	keys, err := mapKeys(v, o)
+	if err != nil {
+		return err
+	}
+
+	enc := encoderFor(v.Type().Elem())
+
+	w(".{" + n)
+
+	for _, k := range keys {
+		writeIndent(b, o, l+1)
+
+		wb('.')
+		w(formatIdent(k.name))
+		w(" = ")
 
-				w(s)
-			} else if err := marshal(k, b, o, l+1); err != nil {
+		if err := enc(v.MapIndex(k.value), b, o, l+1); err != nil {
+			return err
+		}
+
+		w("," + n)
+	}
+
+	writeIndent(b, o, l)
+
+	wb('}')
+
+	return nil
+}
+
+func encodeStruct(v reflect.Value, b *bytes.Buffer, o Options, l int) error {
+	w, wb, n := b.WriteString, b.WriteByte, newline(o)
+
+	w(".{" + n)
+
+	first := true
+
+	sp := planFor(v.Type())
+
+	for _, f := range sp.fields {
+		fv, ok := fieldForEncode(v, f.index)
+		if !ok || f.rest {
+			continue
+		}
+
+		if f.omitEmpty && isEmptyValue(fv) || f.omitZero && isZero(fv) {
+			continue
+		}
+
+		if !first {
+			w(n)
+		}
+		first = false
+
+		writeIndent(b, o, l+1)
+
+		w(f.prefix)
+
+		if f.quoted {
+			var q bytes.Buffer
+
+			if err := f.encode(fv, &q, o, l+1); err != nil {
 				return err
 			}
 
-			w(" = ")
+			w(quote(q.String()))
+		} else if err := f.encode(fv, b, o, l+1); err != nil {
+			return err
+		}
 
-			value := v.MapIndex(k)
+		w(",")
+	}
 
-			if err := marshal(value, b, o, l+1); err != nil {
+	if sp.rest != nil {
+		if fv, ok := fieldForEncode(v, sp.rest.index); ok {
+			entries, err := restEntries(fv, sp)
+			if err != nil {
 				return err
 			}
 
-			w(",")
+			for _, e := range entries {
+				if !first {
+					w(n)
+				}
+				first = false
 
-			if i != len(keys)-1 {
-				w(n)
-			} else {
-				w(n)
+				writeIndent(b, o, l+1)
+
+				wb('.')
+				w(formatIdent(e.name))
+				w(" = ")
+
+				if e.raw != nil {
+					writeRaw(b, e.raw, o, l+1)
+				} else if err := marshal(e.value, b, o, l+1); err != nil {
+					return err
+				}
+
+				w(",")
 			}
 		}
+	}
 
-		writeIndent(b, o, l)
+	w(n)
 
-		wb('}')
-	case reflect.Struct:
-		w(".{" + n)
+	writeIndent(b, o, l)
 
-		first := true
+	wb('}')
 
-		for i := 0; i < v.NumField(); i++ {
-			f := v.Type().Field(i)
+	return nil
+}
 
-			if f.PkgPath != "" {
-				continue
-			}
+// encodeElem writes the value a pointer or interface v points to, or null if v is nil.
+func encodeElem(v reflect.Value, b *bytes.Buffer, o Options, l int) error {
+	if v.IsNil() {
+		b.WriteString("null")
 
-			var (
-				fv        = v.Field(i)
-				tag       = f.Tag.Get("zon")
-				name      = f.Name
-				omitempty = false
-			)
-
-			if tag != "" {
-				parts := strings.Split(tag, ",")
-				if parts[0] != "" {
-					name = strings.TrimSpace(parts[0])
-				}
+		return nil
+	}
 
-				for _, instr := range parts[1:] {
-					if strings.TrimSpace(instr) == "omitempty" {
-						omitempty = true
-					}
-				}
-			}
+	return marshal(v.Elem(), b, o, l)
+}
 
-			if omitempty && isEmptyValue(fv) {
-				continue
-			}
+// newline returns the separator written after the entries of a struct or list.
+func newline(o Options) string {
+	if o.Indent == "" {
+		return " "
+	}
 
-			if !first {
-				w(n)
-			}
-			first = false
+	return "\n"
+}
 
-			writeIndent(b, o, l+1)
+// restEntry is a field collected by a rest field, holding either a value or raw source.
+type restEntry struct {
+	name  string
+	value reflect.Value
+	raw   []byte
+}
 
-			wb('.')
-			w(name)
-			w(" = ")
+// restEntries returns the entries of the rest field v, in sorted order for maps.
+// Entries that have the name of one of the struct fields are reported as an error.
+func restEntries(v reflect.Value, sp *structPlan) ([]restEntry, error) {
+	var entries []restEntry
 
-			if err := marshal(fv, b, o, l+1); err != nil {
-				return err
-			}
+	if v.Type() == rawValueType {
+		if len(bytes.TrimSpace(v.Bytes())) == 0 {
+			return nil, nil
+		}
 
-			w(",")
+		raw, err := rawFields(v.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("zon: invalid rest field: %w", err)
 		}
 
-		w(n)
+		for _, r := range raw {
+			entries = append(entries, restEntry{name: r.name, raw: r.value})
+		}
+	} else {
+		keys := v.MapKeys()
 
-		writeIndent(b, o, l)
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(a.String(), b.String())
+		})
 
-		wb('}')
-	case reflect.Pointer, reflect.Interface:
-		if v.IsNil() {
-			w("null")
-		} else {
-			return marshal(v.Elem(), b, o, l)
+		for _, k := range keys {
+			entries = append(entries, restEntry{name: k.String(), value: v.MapIndex(k)})
 		}
+	}
+
+	for _, e := range entries {
+		if sp.lookup(e.name, false) != nil {
+			return nil, fmt.Errorf("zon: rest field has an entry for field %q", e.name)
+		}
+	}
+
+	return entries, nil
+}
+
+// mapKey is a map key with the field name it is encoded as.
+type mapKey struct {
+	name  string
+	value reflect.Value
+}
+
+// mapKeys returns the keys of the map v in the order they are encoded:
+// by o.KeyCompare if set, or else integer keys in numeric order and other keys by name.
+func mapKeys(v reflect.Value, o Options) ([]mapKey, error) {
+	keys := make([]mapKey, v.Len())
+
+	for i, k := range v.MapKeys() {
+		name, err := keyName(k)
+		if err != nil {
+			return nil, err
+		}
+
+		keys[i] = mapKey{name: name, value: k}
+	}
+
+	if o.KeyCompare != nil {
+		slices.SortFunc(keys, func(a, b mapKey) int {
+			return o.KeyCompare(a.name, b.name)
+		})
+
+		return keys, nil
+	}
+
+	slices.SortFunc(keys, func(a, b mapKey) int {
+		ka, kb := keyElem(a.value), keyElem(b.value)
+
+		if r := cmp.Compare(keyRank(ka), keyRank(kb)); r != 0 {
+			return r
+		}
+
+		switch keyRank(ka) {
+		case 0:
+			return cmp.Compare(ka.Int(), kb.Int())
+		case 1:
+			return cmp.Compare(ka.Uint(), kb.Uint())
+		default:
+			return strings.Compare(a.name, b.name)
+		}
+	})
+
+	return keys, nil
+}
+
+// keyElem returns the dynamic value of a key from a map with interface keys.
+func keyElem(k reflect.Value) reflect.Value {
+	if k.Kind() == reflect.Interface && !k.IsNil() {
+		return k.Elem()
+	}
+
+	return k
+}
+
+// keyRank orders the kinds of map keys, so that keys of different types
+// in a map with interface keys sort deterministically.
+func keyRank(k reflect.Value) int {
+	switch {
+	case k.Kind() == reflect.String, k.Type().Implements(textMarshalerType):
+		return 2
+	case k.CanInt():
+		return 0
+	case k.CanUint():
+		return 1
 	default:
-		return fmt.Errorf("zon: unsupported type %s", v.Type())
+		return 2
 	}
+}
 
-	return nil
+// keyName returns the field name a map key is encoded as. Registered enum keys use their name,
+// string keys are used as is, keys implementing encoding.TextMarshaler
+// use their text, and integer keys are formatted in decimal.
+func keyName(k reflect.Value) (string, error) {
+	k = keyElem(k)
+
+	if e := lookupEnum(k.Type()); e != nil {
+		return e.name(k)
+	}
+
+	if k.Kind() == reflect.String {
+		return k.String(), nil
+	}
+
+	if k.Type().Implements(textMarshalerType) {
+		if k.Kind() == reflect.Pointer && k.IsNil() {
+			return "", nil
+		}
+
+		text, err := k.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return "", fmt.Errorf("zon: cannot marshal map key %s: %w", k.Type(), err)
+		}
+
+		return string(text), nil
+	}
+
+	switch {
+	case k.CanInt():
+		return strconv.FormatInt(k.Int(), 10), nil
+	case k.CanUint():
+		return strconv.FormatUint(k.Uint(), 10), nil
+	default:
+		return "", fmt.Errorf("zon: unsupported map key type %s", k.Type())
+	}
 }
 
 func writeIndent(b *bytes.Buffer, o Options, l int) {
@@ -190,6 +554,32 @@ func writeIndent(b *bytes.Buffer, o Options, l int) {
 	}
 }
 
+// writeMultiline writes s as a multiline string literal with its lines indented
+// one level deeper than l. The literal starts on a new line and ends with a newline
+// followed by the indentation for level l, so that a following comma is not part of the string.
+func writeMultiline(b *bytes.Buffer, o Options, s string, l int) {
+	b.Truncate(len(bytes.TrimRight(b.Bytes(), " \t\n")))
+
+	top := b.Len() == 0
+
+	for i, line := range strings.Split(s, "\n") {
+		if i > 0 || !top {
+			b.WriteByte('\n')
+		}
+
+		if !top {
+			writeIndent(b, o, l+1)
+		}
+
+		b.WriteString(`\\`)
+		b.WriteString(line)
+	}
+
+	b.WriteByte('\n')
+
+	writeIndent(b, o, l)
+}
+
 func isEmptyValue(v reflect.Value) bool {
 	switch v.Kind() {
 	case reflect.Array, reflect.Slice, reflect.Map, reflect.String:
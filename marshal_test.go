@@ -1,6 +1,12 @@
 package zon
 
-import "testing"
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+)
 
 func TestMarshal(t *testing.T) {
 	for _, tt := range []struct {
@@ -25,3 +31,291 @@ func TestMarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestMarshalMultilineStrings(t *testing.T) {
+	v := struct {
+		Description string   `zon:"description"`
+		Lines       []string `zon:"lines"`
+		Name        string   `zon:"name"`
+	}{
+		Description: "first line\nsecond line\n",
+		Lines:       []string{"a\nb", "c"},
+		Name:        "single",
+	}
+
+	for _, tt := range []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{"disabled", nil, ".{\n    .description = \"first line\\nsecond line\\n\",\n    .lines = .{\n        \"a\\nb\",\n        \"c\",\n    },\n    .name = \"single\",\n}\n"},
+		{"enabled", []Option{MultilineStrings(true)}, ".{\n    .description =\n        \\\\first line\n        \\\\second line\n        \\\\\n    ,\n    .lines = .{\n            \\\\a\n            \\\\b\n        ,\n        \"c\",\n    },\n    .name = \"single\",\n}\n"},
+		{"tab indent", []Option{MultilineStrings(true), Indent("\t")}, ".{\n\t.description =\n\t\t\\\\first line\n\t\t\\\\second line\n\t\t\\\\\n\t,\n\t.lines = .{\n\t\t\t\\\\a\n\t\t\t\\\\b\n\t\t,\n\t\t\"c\",\n\t},\n\t.name = \"single\",\n}\n"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := Marshal(v, tt.opts...)
+			if err != nil {
+				t.Fatalf("Marshal returned error: %v", err)
+			}
+
+			if got := string(data); got != tt.want {
+				t.Fatalf("Marshal = %q, want %q", got, tt.want)
+			}
+
+			var got struct {
+				Description string   `zon:"description"`
+				Lines       []string `zon:"lines"`
+				Name        string   `zon:"name"`
+			}
+
+			if err := Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal returned error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, v) {
+				t.Fatalf("round trip = %+v, want %+v", got, v)
+			}
+		})
+	}
+}
+
+func TestMarshalRuneLiterals(t *testing.T) {
+	v := struct {
+		R rune  `zon:"r"`
+		N int32 `zon:"n"`
+		B byte  `zon:"b"`
+	}{R: 'é', N: -1, B: 'x'}
+
+	for _, tt := range []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{"disabled", []Option{Indent("")}, ".{ .r = 233, .n = -1, .b = 120, }\n"},
+		{"enabled", []Option{Indent(""), RuneLiterals(true)}, ".{ .r = 'é', .n = -1, .b = 120, }\n"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := Marshal(v, tt.opts...)
+			if err != nil {
+				t.Fatalf("Marshal returned error: %v", err)
+			}
+
+			if got := string(data); got != tt.want {
+				t.Fatalf("Marshal = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarshalBigInt(t *testing.T) {
+	n, _ := new(big.Int).SetString("-340282366920938463463374607431768211455", 10)
+
+	v := struct {
+		Ptr   *big.Int `zon:"ptr"`
+		Value big.Int  `zon:"value"`
+		Nil   *big.Int `zon:"nil"`
+	}{Ptr: n, Value: *big.NewInt(42)}
+
+	data, err := Marshal(v, Indent(""))
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	want := ".{ .ptr = -340282366920938463463374607431768211455, .value = 42, .nil = null, }\n"
+
+	if got := string(data); got != want {
+		t.Fatalf("Marshal = %q, want %q", got, want)
+	}
+}
+
+type version struct {
+	Major int `zon:"major"`
+	Minor int `zon:"minor,omitempty"`
+}
+
+func (v version) IsZero() bool { return v.Major < 0 }
+
+func TestMarshalTagOptions(t *testing.T) {
+	v := struct {
+		Name    string  `zon:"name"`
+		Skip    string  `zon:"-"`
+		Empty   []int   `zon:"empty,omitempty"`
+		Zero    version `zon:"zero,omitzero"`
+		Set     version `zon:"set,omitzero"`
+		Port    uint16  `zon:"port,string"`
+		Enabled bool    `zon:"enabled,string"`
+		Ratio   float64 `zon:"ratio,string"`
+		Text    string  `zon:"text,string"`
+	}{
+		Name:    "zon",
+		Skip:    "skipped",
+		Empty:   []int{},
+		Zero:    version{Major: -1},
+		Set:     version{Major: 0, Minor: 14},
+		Port:    8080,
+		Enabled: true,
+		Ratio:   0.5,
+		Text:    "plain",
+	}
+
+	data, err := Marshal(v, Indent(""))
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	want := `.{ .name = "zon", .set = .{ .major = 0, .minor = 14, }, .port = "8080", .enabled = "true", .ratio = "0.5", .text = "plain", }` + "\n"
+
+	if got := string(data); got != want {
+		t.Fatalf("Marshal = %q, want %q", got, want)
+	}
+}
+
+func TestMarshalNumber(t *testing.T) {
+	v := struct {
+		Hex   Number `zon:"hex"`
+		Float Number `zon:"float"`
+		Empty Number `zon:"empty"`
+	}{Hex: "0xff", Float: "-1.5e3"}
+
+	data, err := Marshal(v, Indent(""))
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	want := ".{ .hex = 0xff, .float = -1.5e3, .empty = 0, }\n"
+
+	if got := string(data); got != want {
+		t.Fatalf("Marshal = %q, want %q", got, want)
+	}
+
+	if _, err := Marshal(Number("1 }")); err == nil {
+		t.Fatal("Marshal of invalid Number returned no error")
+	}
+}
+
+func TestMarshalLegacyLiterals(t *testing.T) {
+	v := []any{".env", "0xdead", EnumLiteral("env"), "plain"}
+
+	for _, tt := range []struct {
+		opts []Option
+		want string
+	}{
+		{nil, `.{ ".env", "0xdead", .env, "plain", }`},
+		{[]Option{LegacyLiterals(true)}, `.{ .env, 0xdead, .env, "plain", }`},
+	} {
+		data, err := Marshal(v, append(tt.opts, Indent(""))...)
+		if err != nil {
+			t.Fatalf("Marshal returned error: %v", err)
+		}
+
+		if got := strings.TrimSpace(string(data)); got != tt.want {
+			t.Fatalf("Marshal = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+type textKey struct{ a, b string }
+
+func (k textKey) MarshalText() ([]byte, error) {
+	return []byte(k.a + "-" + k.b), nil
+}
+
+func TestMarshalMapKeys(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		v    any
+		opts []Option
+		want string
+	}{
+		{
+			name: "strings",
+			v:    map[string]int{"b": 2, "c": 3, "a": 1, "with space": 4},
+			want: `.{ .a = 1, .b = 2, .c = 3, .@"with space" = 4, }`,
+		},
+		{
+			name: "leading dot",
+			v:    map[string]int{".env": 1, "env": 2},
+			want: `.{ .@".env" = 1, .env = 2, }`,
+		},
+		{
+			name: "ints",
+			v:    map[int]string{10: "ten", -1: "minus one", 2: "two"},
+			want: `.{ .@"-1" = "minus one", .@"2" = "two", .@"10" = "ten", }`,
+		},
+		{
+			name: "uints",
+			v:    map[uint8]bool{20: true, 3: false},
+			want: `.{ .@"3" = false, .@"20" = true, }`,
+		},
+		{
+			name: "text",
+			v:    map[textKey]int{{"b", "a"}: 2, {"a", "b"}: 1},
+			want: `.{ .@"a-b" = 1, .@"b-a" = 2, }`,
+		},
+		{
+			name: "interface",
+			v:    map[any]int{"x": 3, 2: 2, 1: 1},
+			want: `.{ .@"1" = 1, .@"2" = 2, .x = 3, }`,
+		},
+		{
+			name: "comparator",
+			v:    map[string]int{"a": 1, "bb": 2, "ccc": 3},
+			opts: []Option{MapKeyOrder(func(a, b string) int { return len(b) - len(a) })},
+			want: `.{ .ccc = 3, .bb = 2, .a = 1, }`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 10 {
+				data, err := Marshal(tt.v, append(tt.opts, Indent(""))...)
+				if err != nil {
+					t.Fatalf("Marshal returned error: %v", err)
+				}
+
+				if got := strings.TrimSpace(string(data)); got != tt.want {
+					t.Fatalf("Marshal = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+
+	if _, err := Marshal(map[float64]int{1.5: 1}); err == nil {
+		t.Fatal("Marshal of map with float keys returned no error")
+	}
+}
+
+func TestMarshalFloats(t *testing.T) {
+	v := struct {
+		F32    float32 `zon:"f32"`
+		F64    float64 `zon:"f64"`
+		Inf    float64 `zon:"inf"`
+		NegInf float32 `zon:"neg_inf"`
+		NaN    float64 `zon:"nan"`
+	}{F32: 0.1, F64: 0.1, Inf: math.Inf(1), NegInf: float32(math.Inf(-1)), NaN: math.NaN()}
+
+	data, err := Marshal(v, Indent(""))
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	want := ".{ .f32 = 0.1, .f64 = 0.1, .inf = inf, .neg_inf = -inf, .nan = nan, }\n"
+
+	if got := string(data); got != want {
+		t.Fatalf("Marshal = %q, want %q", got, want)
+	}
+
+	var got struct {
+		F32    float32 `zon:"f32"`
+		F64    float64 `zon:"f64"`
+		Inf    float64 `zon:"inf"`
+		NegInf float32 `zon:"neg_inf"`
+		NaN    float64 `zon:"nan"`
+	}
+
+	if err := Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if got.F32 != v.F32 || got.F64 != v.F64 || got.Inf != v.Inf || got.NegInf != v.NegInf || !math.IsNaN(got.NaN) {
+		t.Fatalf("round trip = %+v, want %+v", got, v)
+	}
+}
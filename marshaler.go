@@ -0,0 +1,203 @@
+package zon
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+)
+
+// Marshaler is implemented by types that encode themselves as ZON.
+//
+// MarshalZON must return a single valid ZON value. It is re-indented to fit
+// the surrounding output, and comments in it are dropped.
+type Marshaler interface {
+	MarshalZON() ([]byte, error)
+}
+
+// Unmarshaler is implemented by types that decode themselves from ZON.
+//
+// UnmarshalZON receives the source of a single value as is, including comments
+// between its first and last token. A null value is not passed to UnmarshalZON.
+type Unmarshaler interface {
+	UnmarshalZON(data []byte) error
+}
+
+var (
+	marshalerType   = reflect.TypeFor[Marshaler]()
+	unmarshalerType = reflect.TypeFor[Unmarshaler]()
+)
+
+// marshaler returns the Marshaler implemented by v or by a pointer to v, if any.
+// Nil pointers and interfaces are left to be encoded as null.
+func marshaler(v reflect.Value) (Marshaler, bool) {
+	if !v.CanInterface() {
+		return nil, false
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		return nil, false
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil, false
+		}
+	}
+
+	h := hooksFor(v.Type())
+
+	if h.marshaler {
+		return v.Interface().(Marshaler), true
+	}
+
+	if !h.ptrMarshaler {
+		return nil, false
+	}
+
+	if !v.CanAddr() {
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		v = c
+	}
+
+	return v.Addr().Interface().(Marshaler), true
+}
+
+// unmarshaler returns the Unmarshaler implemented by v or by a pointer to v, if any.
+func unmarshaler(v reflect.Value) (Unmarshaler, bool) {
+	if !v.CanInterface() || v.Kind() == reflect.Interface {
+		return nil, false
+	}
+
+	h := hooksFor(v.Type())
+
+	if v.CanAddr() && h.ptrUnmarshaler {
+		return v.Addr().Interface().(Unmarshaler), true
+	}
+
+	if h.unmarshaler {
+		return v.Interface().(Unmarshaler), true
+	}
+
+	return nil, false
+}
+
+// marshalCustom writes the output of m, re-indented for level l.
+func marshalCustom(m Marshaler, t reflect.Type, b *bytes.Buffer, o Options, l int) error {
+	data, err := m.MarshalZON()
+	if err != nil {
+		return fmt.Errorf("zon: error calling MarshalZON for type %s: %w", t, err)
+	}
+
+	if err := validRaw(data); err != nil {
+		return fmt.Errorf("zon: invalid output from MarshalZON for type %s: %w", t, err)
+	}
+
+	return newParser(data, UnmarshalOptions{}).reindent(b, o, l)
+}
+
+// parseUnmarshaler passes the source of the next value to u, which is stored in v.
+func (p *parser) parseUnmarshaler(v reflect.Value, u Unmarshaler) error {
+	tok, err := p.peek()
+	if err != nil {
+		return err
+	}
+
+	raw, err := p.rawValue()
+	if err != nil {
+		return err
+	}
+
+	if err := u.UnmarshalZON(raw); err != nil {
+		return p.methodError(tok, "UnmarshalZON", v.Type(), err)
+	}
+
+	return nil
+}
+
+// methodError wraps the error returned by the method of type t that decoded the value at tok,
+// adding the ZON path and position of the value.
+func (p *parser) methodError(tok Token, method string, t reflect.Type, err error) error {
+	line, column, _ := position(p.s.data, tok.Offset)
+
+	at := fmt.Sprintf("line %d, column %d", line, column)
+
+	if path := p.pathString(); path != "" {
+		at = path + ", " + at
+	}
+
+	return fmt.Errorf("zon: error calling %s for type %s at %s: %w", method, t, at, err)
+}
+
+// reindent writes the next value, which must be valid, in the layout used by Marshal at level l.
+func (p *parser) reindent(b *bytes.Buffer, o Options, l int) error {
+	tok, err := p.next()
+	if err != nil {
+		return err
+	}
+
+	switch tok.Kind {
+	case TokenLBrace:
+		n := "\n"
+
+		if o.Indent == "" {
+			n = " "
+		}
+
+		b.WriteString(".{" + n)
+
+		for {
+			next, err := p.peek()
+			if err != nil {
+				return err
+			}
+
+			if next.Kind == TokenRBrace {
+				p.next()
+
+				break
+			}
+
+			if next.Kind == TokenComma {
+				p.next()
+
+				continue
+			}
+
+			writeIndent(b, o, l+1)
+
+			if eq, err := p.peekN(1); err == nil && next.Kind == TokenEnumLiteral && eq.Kind == TokenEqual {
+				key, err := p.parseKey()
+				if err != nil {
+					return err
+				}
+
+				b.WriteString("." + formatIdent(key) + " = ")
+			}
+
+			if err := p.reindent(b, o, l+1); err != nil {
+				return err
+			}
+
+			b.WriteString("," + n)
+		}
+
+		writeIndent(b, o, l)
+
+		b.WriteByte('}')
+	case TokenMultilineString:
+		s, err := Unquote(tok.Text)
+		if err != nil {
+			return err
+		}
+
+		writeMultiline(b, o, s, l)
+	case TokenMinus:
+		b.WriteByte('-')
+
+		return p.reindent(b, o, l)
+	default:
+		b.WriteString(tok.Text)
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package zon
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+type testPoint struct{ X, Y int }
+
+func (p testPoint) MarshalZON() ([]byte, error) {
+	return []byte(".{\n\t// x and y\n  " + strconv.Itoa(p.X) + ",\n" + strconv.Itoa(p.Y) + "}"), nil
+}
+
+func (p *testPoint) UnmarshalZON(data []byte) error {
+	var v []int
+
+	if err := Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	if len(v) != 2 {
+		return errors.New("point needs two coordinates")
+	}
+
+	p.X, p.Y = v[0], v[1]
+
+	return nil
+}
+
+type testRaw struct {
+	data string
+	err  error
+}
+
+func (r *testRaw) UnmarshalZON(data []byte) error {
+	r.data = string(data)
+
+	return r.err
+}
+
+type testBad struct{ out string }
+
+func (b testBad) MarshalZON() ([]byte, error) {
+	if b.out == "" {
+		return nil, errors.New("boom")
+	}
+
+	return []byte(b.out), nil
+}
+
+func TestMarshaler(t *testing.T) {
+	type T struct {
+		Point  testPoint  `zon:"point"`
+		Ptr    *testPoint `zon:"ptr"`
+		Nil    *testPoint `zon:"nil"`
+		Points []testPoint
+	}
+
+	v := T{Point: testPoint{1, 2}, Ptr: &testPoint{-3, 4}, Points: []testPoint{{5, 6}}}
+
+	data, err := Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	want := `.{
+    .point = .{
+        1,
+        2,
+    },
+    .ptr = .{
+        -3,
+        4,
+    },
+    .nil = null,
+    .Points = .{
+        .{
+            5,
+            6,
+        },
+    },
+}
+`
+
+	if got := string(data); got != want {
+		t.Fatalf("Marshal =\n%s\nwant\n%s", got, want)
+	}
+
+	data, err = Marshal(v, Indent(""))
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	if got, want := string(data), ".{ .point = .{ 1, 2, }, .ptr = .{ -3, 4, }, .nil = null, .Points = .{ .{ 5, 6, }, }, }\n"; got != want {
+		t.Fatalf("Marshal = %q, want %q", got, want)
+	}
+
+	var out T
+
+	if err := Unmarshal(data, &out, Strict(true)); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if out.Point != v.Point || *out.Ptr != *v.Ptr || out.Nil != nil || out.Points[0] != v.Points[0] {
+		t.Fatalf("out = %+v, want %+v", out, v)
+	}
+
+	for _, bad := range []testBad{{}, {out: ".{ 1"}, {out: "1 2"}, {out: "foo"}, {out: ".{ .a = 1 .b = 2 }"}} {
+		if _, err := Marshal(bad); err == nil {
+			t.Errorf("Marshal of %+v returned no error", bad)
+		}
+	}
+
+	data, err = Marshal(testBad{out: `.{ .@"a b" = -inf, .s = "x" , }`}, Indent(""))
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	if got, want := string(data), `.{ .@"a b" = -inf, .s = "x", }`+"\n"; got != want {
+		t.Fatalf("Marshal = %q, want %q", got, want)
+	}
+}
+
+func TestUnmarshaler(t *testing.T) {
+	var v struct {
+		Raw   testRaw  `zon:"raw"`
+		Ptr   *testRaw `zon:"ptr"`
+		After int      `zon:"after"`
+	}
+
+	in := ".{\n    .raw = .{ .a = 1, // comment\n        .b = \"}\" },\n    .ptr = 'x', .after = 1 }"
+
+	if err := Unmarshal([]byte(in), &v); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if want := ".{ .a = 1, // comment\n        .b = \"}\" }"; v.Raw.data != want {
+		t.Fatalf("Raw = %q, want %q", v.Raw.data, want)
+	}
+
+	if v.Ptr == nil || v.Ptr.data != "'x'" || v.After != 1 {
+		t.Fatalf("v = %+v", v)
+	}
+
+	var p testPoint
+
+	err := Unmarshal([]byte(`.{ 1 }`), &p)
+	if want := "zon: error calling UnmarshalZON for type zon.testPoint at line 1, column 1: point needs two coordinates"; err == nil || err.Error() != want {
+		t.Fatalf("Unmarshal error = %v, want %s", err, want)
+	}
+
+	var ps struct {
+		Points []testPoint `zon:"points"`
+	}
+
+	err = Unmarshal([]byte(".{ .points = .{\n  .{ 1, 2 }, .{ 3 } } }"), &ps)
+	if want := "zon: error calling UnmarshalZON for type zon.testPoint at .points[1], line 2, column 14: point needs two coordinates"; err == nil || err.Error() != want {
+		t.Fatalf("Unmarshal error = %v, want %s", err, want)
+	}
+
+	var raw testRaw
+
+	raw.err = errors.New("boom")
+
+	if err := Unmarshal([]byte(`1`), &raw); !errors.Is(err, raw.err) {
+		t.Fatalf("Unmarshal error = %v, want it to wrap %v", err, raw.err)
+	}
+}
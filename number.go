@@ -0,0 +1,381 @@
+package zon
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	bigIntType   = reflect.TypeFor[big.Int]()
+	bigFloatType = reflect.TypeFor[big.Float]()
+	numberType   = reflect.TypeFor[Number]()
+)
+
+// Number is a ZON number literal, kept as spelled in the input.
+//
+// Numbers decoded into an any value are Numbers when the UseNumber option is set.
+// A Number is encoded as its literal, or 0 if empty.
+type Number string
+
+// String returns the literal of n.
+func (n Number) String() string {
+	return string(n)
+}
+
+// Int64 returns n as an int64.
+func (n Number) Int64() (int64, error) {
+	i, err := n.BigInt()
+	if err != nil {
+		return 0, err
+	}
+
+	if !i.IsInt64() {
+		return 0, fmt.Errorf("zon: integer literal %s overflows int64: %w", n, ErrOverflow)
+	}
+
+	return i.Int64(), nil
+}
+
+// BigInt returns n as a big.Int.
+func (n Number) BigInt() (*big.Int, error) {
+	neg, base, digits, err := parseIntLiteral(string(n))
+	if err != nil {
+		return nil, fmt.Errorf("zon: %w: %w", ErrInvalidNumber, err)
+	}
+
+	i, ok := new(big.Int).SetString(digits, base)
+	if !ok {
+		return nil, fmt.Errorf("zon: %w: %q", ErrInvalidNumber, n)
+	}
+
+	if neg {
+		i.Neg(i)
+	}
+
+	return i, nil
+}
+
+// Float64 returns n as a float64.
+func (n Number) Float64() (float64, error) {
+	f, err := parseFloatLiteral(string(n), 64)
+	if err != nil {
+		return 0, fmt.Errorf("zon: %w: %w", ErrInvalidNumber, err)
+	}
+
+	return f, nil
+}
+
+// valid reports whether n is an integer or float literal, or one of inf, -inf and nan.
+func (n Number) valid() bool {
+	if isFloatLiteral(string(n)) {
+		_, err := n.Float64()
+
+		return err == nil || errors.Is(err, strconv.ErrRange)
+	}
+
+	_, err := n.BigInt()
+
+	return err == nil
+}
+
+// f128Prec is the mantissa precision of a Zig f128, used when
+// decoding into a big.Float that has no precision set.
+const f128Prec = 113
+
+// scanNumber returns the position just after the number literal starting at data[pos].
+//
+// Like the Zig tokenizer it consumes every character that may be part of a number
+// (digits, letters, underscores, periods and exponent signs), leaving validation
+// of the literal to parseIntLiteral.
+func scanNumber(data []byte, pos int) int {
+	i := pos
+
+	if i < len(data) && data[i] == '-' {
+		i++
+	}
+
+	hex := hasPrefixAt(data, i, "0x")
+
+	for i < len(data) {
+		c := data[i]
+
+		switch {
+		case isDigit(c), isLetter(c), c == '_', c == '.':
+			i++
+		case c == '+' || c == '-':
+			prev := data[i-1]
+
+			if hex && prev != 'p' && prev != 'P' || !hex && prev != 'e' && prev != 'E' {
+				return i
+			}
+
+			i++
+		default:
+			return i
+		}
+	}
+
+	return i
+}
+
+// parseIntLiteral validates the Zig integer literal s, returning its sign,
+// base and digits with the base prefix and underscores removed.
+func parseIntLiteral(s string) (neg bool, base int, digits string, err error) {
+	lit := s
+
+	if strings.HasPrefix(s, "-") {
+		neg, s = true, s[1:]
+	}
+
+	base = 10
+
+	if len(s) >= 2 && s[0] == '0' {
+		switch s[1] {
+		case 'b':
+			base = 2
+		case 'o':
+			base = 8
+		case 'x':
+			base = 16
+		}
+
+		if base != 10 {
+			s = s[2:]
+		}
+	}
+
+	if base == 10 && len(s) > 1 && s[0] == '0' {
+		return false, 0, "", fmt.Errorf("integer literal %q has leading zero", lit)
+	}
+
+	b := make([]byte, 0, len(s))
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		if c == '_' {
+			if i == 0 || i == len(s)-1 || s[i+1] == '_' {
+				return false, 0, "", fmt.Errorf("invalid underscore in integer literal %q", lit)
+			}
+
+			continue
+		}
+
+		if digitValue(c) >= base {
+			return false, 0, "", fmt.Errorf("invalid digit %q in integer literal %q", c, lit)
+		}
+
+		b = append(b, c)
+	}
+
+	if len(b) == 0 {
+		return false, 0, "", fmt.Errorf("invalid integer literal %q", lit)
+	}
+
+	return neg, base, string(b), nil
+}
+
+// scanIdent returns the position just after the bare identifier starting at data[pos].
+func scanIdent(data []byte, pos int) int {
+	i := pos
+
+	for i < len(data) && (isLetter(data[i]) || isDigit(data[i]) || data[i] == '_') {
+		i++
+	}
+
+	return i
+}
+
+// parseFloatLiteral parses the Zig float literal s, which may also be an integer
+// literal or one of inf, -inf and nan, into a float of the given bit size.
+func parseFloatLiteral(s string, bits int) (float64, error) {
+	switch s {
+	case "inf":
+		return math.Inf(1), nil
+	case "-inf":
+		return math.Inf(-1), nil
+	case "nan":
+		return math.NaN(), nil
+	}
+
+	lit, err := normalizeFloatLiteral(s)
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := strconv.ParseFloat(lit, bits)
+	if err != nil {
+		return 0, fmt.Errorf("float literal %s overflows float%d: %w", s, bits, strconv.ErrRange)
+	}
+
+	return f, nil
+}
+
+// parseBigFloatLiteral parses the Zig float literal s into a big.Float with the given precision.
+func parseBigFloatLiteral(s string, prec uint) (*big.Float, error) {
+	switch s {
+	case "inf":
+		return new(big.Float).SetPrec(prec).SetInf(false), nil
+	case "-inf":
+		return new(big.Float).SetPrec(prec).SetInf(true), nil
+	case "nan":
+		return nil, fmt.Errorf("float literal nan cannot be represented by big.Float")
+	}
+
+	lit, err := normalizeFloatLiteral(s)
+	if err != nil {
+		return nil, err
+	}
+
+	f, _, err := new(big.Float).SetPrec(prec).Parse(lit, 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid float literal %q", s)
+	}
+
+	return f, nil
+}
+
+// normalizeFloatLiteral validates the Zig float literal s and rewrites it into
+// a form understood by strconv.ParseFloat and big.Float.Parse.
+func normalizeFloatLiteral(s string) (string, error) {
+	lit := s
+
+	var b strings.Builder
+
+	if strings.HasPrefix(s, "-") {
+		b.WriteByte('-')
+
+		s = s[1:]
+	}
+
+	base, exp := 10, "eE"
+
+	if strings.HasPrefix(s, "0x") {
+		b.WriteString("0x")
+
+		s, base, exp = s[2:], 16, "pP"
+	}
+
+	mantissa, exponent, hasExp := s, "", false
+
+	if i := strings.IndexAny(s, exp); i >= 0 {
+		mantissa, exponent, hasExp = s[:i], s[i+1:], true
+	}
+
+	whole, frac, hasFrac := strings.Cut(mantissa, ".")
+
+	if !validDigits(whole, base) || hasFrac && !validDigits(frac, base) {
+		return "", fmt.Errorf("invalid float literal %q", lit)
+	}
+
+	if base == 10 && len(whole) > 1 && whole[0] == '0' {
+		return "", fmt.Errorf("float literal %q has leading zero", lit)
+	}
+
+	b.WriteString(strings.ReplaceAll(whole, "_", ""))
+
+	if hasFrac {
+		b.WriteByte('.')
+		b.WriteString(strings.ReplaceAll(frac, "_", ""))
+	}
+
+	if hasExp {
+		sign := ""
+
+		if strings.HasPrefix(exponent, "+") || strings.HasPrefix(exponent, "-") {
+			sign, exponent = exponent[:1], exponent[1:]
+		}
+
+		if !validDigits(exponent, 10) {
+			return "", fmt.Errorf("invalid exponent in float literal %q", lit)
+		}
+
+		b.WriteByte(exp[0])
+		b.WriteString(sign)
+		b.WriteString(strings.ReplaceAll(exponent, "_", ""))
+	} else if base == 16 {
+		b.WriteString("p0")
+	}
+
+	return b.String(), nil
+}
+
+// validDigits reports whether s is a non-empty sequence of digits in the given base,
+// optionally separated by single underscores.
+func validDigits(s string, base int) bool {
+	if s == "" || s[0] == '_' || s[len(s)-1] == '_' || strings.Contains(s, "__") {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '_' && digitValue(s[i]) >= base {
+			return false
+		}
+	}
+
+	return true
+}
+
+// formatFloat returns f formatted as a ZON float literal.
+func formatFloat(f float64, bits int) string {
+	switch {
+	case math.IsNaN(f):
+		return "nan"
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	default:
+		return strconv.FormatFloat(f, 'g', -1, bits)
+	}
+}
+
+// formatBigFloat returns f formatted as a ZON float literal.
+func formatBigFloat(f *big.Float) string {
+	if f.IsInf() {
+		if f.Signbit() {
+			return "-inf"
+		}
+
+		return "inf"
+	}
+
+	return f.Text('g', -1)
+}
+
+// isFloatLiteral reports whether the number literal s is a float rather than an integer.
+func isFloatLiteral(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+
+	if s == "inf" || s == "nan" {
+		return true
+	}
+
+	if strings.HasPrefix(s, "0x") {
+		return strings.ContainsAny(s, ".pP")
+	}
+
+	return strings.ContainsAny(s, ".eE")
+}
+
+func digitValue(c byte) int {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0')
+	case c >= 'a' && c <= 'z':
+		return int(c-'a') + 10
+	case c >= 'A' && c <= 'Z':
+		return int(c-'A') + 10
+	default:
+		return 36
+	}
+}
+
+func isLetter(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
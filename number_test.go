@@ -0,0 +1,213 @@
+package zon
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestScanNumber(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want string
+	}{
+		{"123,", "123"},
+		{"-0x1F}", "-0x1F"},
+		{"1_000_000 ", "1_000_000"},
+		{"1.5e-3,", "1.5e-3"},
+		{"0x1.8p+3,", "0x1.8p+3"},
+		{"0xe-1", "0xe"},
+		{"1-2", "1"},
+		{"12abc", "12abc"},
+	} {
+		if got := tt.in[:scanNumber([]byte(tt.in), 0)]; got != tt.want {
+			t.Errorf("scanNumber(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseIntLiteral(t *testing.T) {
+	for _, tt := range []struct {
+		in     string
+		neg    bool
+		base   int
+		digits string
+	}{
+		{"0", false, 10, "0"},
+		{"42", false, 10, "42"},
+		{"-42", true, 10, "42"},
+		{"1_000", false, 10, "1000"},
+		{"0b1010_1010", false, 2, "10101010"},
+		{"0o755", false, 8, "755"},
+		{"0xdead_BEEF", false, 16, "deadBEEF"},
+		{"-0x80", true, 16, "80"},
+	} {
+		neg, base, digits, err := parseIntLiteral(tt.in)
+		if err != nil {
+			t.Fatalf("parseIntLiteral(%q) returned error: %v", tt.in, err)
+		}
+
+		if neg != tt.neg || base != tt.base || digits != tt.digits {
+			t.Errorf("parseIntLiteral(%q) = %v, %d, %q, want %v, %d, %q", tt.in, neg, base, digits, tt.neg, tt.base, tt.digits)
+		}
+	}
+
+	for _, in := range []string{"", "-", "0x", "007", "1__0", "_1", "1_", "0x_1", "0b102", "0o8", "0xg", "12abc", "0X10", "1.5"} {
+		if _, _, _, err := parseIntLiteral(in); err == nil {
+			t.Errorf("parseIntLiteral(%q) returned no error", in)
+		}
+	}
+}
+
+func TestIsFloatLiteral(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want bool
+	}{
+		{"1", false},
+		{"0xe", false},
+		{"0xe1", false},
+		{"1e3", true},
+		{"1.5", true},
+		{"-0x1p3", true},
+		{"0x1.8", true},
+	} {
+		if got := isFloatLiteral(tt.in); got != tt.want {
+			t.Errorf("isFloatLiteral(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseFloatLiteral(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		bits int
+		want float64
+	}{
+		{"1.5", 64, 1.5},
+		{"-2.25e3", 64, -2250},
+		{"1e-3", 64, 0.001},
+		{"6.022_140_76e23", 64, 6.02214076e23},
+		{"42", 64, 42},
+		{"0x1.8p3", 64, 12},
+		{"0x1.8", 64, 1.5},
+		{"-0x1p-2", 64, -0.25},
+		{"0xff", 64, 255},
+		{"0x1_0.0p0", 64, 16},
+		{"0.1", 32, float64(float32(0.1))},
+		{"inf", 64, math.Inf(1)},
+		{"-inf", 32, math.Inf(-1)},
+	} {
+		got, err := parseFloatLiteral(tt.in, tt.bits)
+		if err != nil {
+			t.Fatalf("parseFloatLiteral(%q) returned error: %v", tt.in, err)
+		}
+
+		if got != tt.want {
+			t.Errorf("parseFloatLiteral(%q, %d) = %v, want %v", tt.in, tt.bits, got, tt.want)
+		}
+	}
+
+	if got, err := parseFloatLiteral("nan", 64); err != nil || !math.IsNaN(got) {
+		t.Errorf("parseFloatLiteral(nan) = %v, %v", got, err)
+	}
+
+	for _, in := range []string{"", "1.", ".5", "1e", "1._5", "1__0.0", "00.5", "0x1.g", "1p3", "0x1e+3.0", "NaN", "+Inf", "1e400", "foo"} {
+		if _, err := parseFloatLiteral(in, 64); err == nil {
+			t.Errorf("parseFloatLiteral(%q) returned no error", in)
+		}
+	}
+
+	if _, err := parseFloatLiteral("1e39", 32); err == nil {
+		t.Error("parseFloatLiteral(1e39, 32) returned no error")
+	}
+}
+
+func TestFormatFloat(t *testing.T) {
+	for _, tt := range []struct {
+		in   float64
+		bits int
+		want string
+	}{
+		{1.5, 64, "1.5"},
+		{float64(float32(0.1)), 32, "0.1"},
+		{float64(float32(0.1)), 64, "0.10000000149011612"},
+		{1e21, 64, "1e+21"},
+		{math.Inf(1), 64, "inf"},
+		{math.Inf(-1), 64, "-inf"},
+		{math.NaN(), 64, "nan"},
+	} {
+		if got := formatFloat(tt.in, tt.bits); got != tt.want {
+			t.Errorf("formatFloat(%v, %d) = %q, want %q", tt.in, tt.bits, got, tt.want)
+		}
+	}
+}
+
+func TestBigFloatLiteral(t *testing.T) {
+	f, err := parseBigFloatLiteral("0x1.000000000000000000000000001p0", f128Prec)
+	if err != nil {
+		t.Fatalf("parseBigFloatLiteral returned error: %v", err)
+	}
+
+	if f.Prec() != f128Prec {
+		t.Fatalf("f.Prec() = %d, want %d", f.Prec(), f128Prec)
+	}
+
+	if f.Cmp(big.NewFloat(1)) <= 0 {
+		t.Fatalf("f = %s, want a value greater than 1 at f128 precision", f.Text('p', 0))
+	}
+
+	for in, want := range map[string]string{"inf": "inf", "-inf": "-inf", "1.25": "1.25"} {
+		f, err := parseBigFloatLiteral(in, f128Prec)
+		if err != nil {
+			t.Fatalf("parseBigFloatLiteral(%q) returned error: %v", in, err)
+		}
+
+		if got := formatBigFloat(f); got != want {
+			t.Errorf("formatBigFloat(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	if _, err := parseBigFloatLiteral("nan", f128Prec); err == nil {
+		t.Error("parseBigFloatLiteral(nan) returned no error")
+	}
+}
+
+func TestNumber(t *testing.T) {
+	if i, err := Number("0x7fff_ffff_ffff_ffff").Int64(); err != nil || i != math.MaxInt64 {
+		t.Errorf("Int64() = %d, %v", i, err)
+	}
+
+	if _, err := Number("0x8000_0000_0000_0000").Int64(); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Int64() err = %v, want ErrOverflow", err)
+	}
+
+	if _, err := Number("1.5").Int64(); !errors.Is(err, ErrInvalidNumber) {
+		t.Errorf("Int64() err = %v, want ErrInvalidNumber", err)
+	}
+
+	if n, err := Number("-123456789012345678901234567890").BigInt(); err != nil || n.String() != "-123456789012345678901234567890" {
+		t.Errorf("BigInt() = %v, %v", n, err)
+	}
+
+	if f, err := Number("0x1p-2").Float64(); err != nil || f != 0.25 {
+		t.Errorf("Float64() = %v, %v", f, err)
+	}
+
+	if f, err := Number("-inf").Float64(); err != nil || !math.IsInf(f, -1) {
+		t.Errorf("Float64() = %v, %v", f, err)
+	}
+
+	for _, n := range []Number{"1", "-0b101", "1e999", "nan"} {
+		if !n.valid() {
+			t.Errorf("Number(%q).valid() = false", n)
+		}
+	}
+
+	for _, n := range []Number{"", "foo", "1..2", "0x"} {
+		if n.valid() {
+			t.Errorf("Number(%q).valid() = true", n)
+		}
+	}
+}
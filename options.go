@@ -1,5 +1,10 @@
 package zon
 
+import (
+	"fmt"
+	"reflect"
+)
+
 func defaultOptions() Options {
 	return Options{
 		Indent: "    ",
@@ -7,7 +12,13 @@ func defaultOptions() Options {
 }
 
 type Options struct {
-	Indent string
+	Indent    string
+	Multiline bool
+	Runes     bool
+
+	KeyCompare func(a, b string) int // order of map keys by their encoded names, if set
+
+	LegacyLiterals bool // strings like .name and 0xff are written unquoted
 }
 
 type Option func(o *Options)
@@ -17,3 +28,146 @@ func Indent(s string) Option {
 		o.Indent = s
 	}
 }
+
+// MultilineStrings makes strings containing newlines encode as
+// multiline string literals (\\ lines) when they can be represented as such.
+func MultilineStrings(enabled bool) Option {
+	return func(o *Options) {
+		o.Multiline = enabled
+	}
+}
+
+// RuneLiterals makes rune values encode as character literals ('a') instead of decimal numbers.
+// Since rune is an alias for int32, this applies to every int32 that is a valid Unicode codepoint.
+func RuneLiterals(enabled bool) Option {
+	return func(o *Options) {
+		o.Runes = enabled
+	}
+}
+
+// LegacyLiterals makes strings that look like enum literals (.name) or hexadecimal
+// numbers (0xff) encode unquoted, as they did before EnumLiteral was introduced.
+// Otherwise strings are always encoded as string literals.
+func LegacyLiterals(enabled bool) Option {
+	return func(o *Options) {
+		o.LegacyLiterals = enabled
+	}
+}
+
+// MapKeyOrder makes map keys encode in the order given by cmp, which compares
+// the names the keys are written as. Without it, keys are sorted by name,
+// and integer keys in numeric order.
+func MapKeyOrder(cmp func(a, b string) int) Option {
+	return func(o *Options) {
+		o.KeyCompare = cmp
+	}
+}
+
+// UnmarshalOptions configures how ZON is decoded.
+type UnmarshalOptions struct {
+	Strict                bool
+	DisallowUnknownFields bool
+	UseNumber             bool
+	CaseInsensitive       bool
+	EnumStrings           bool         // enum literals decode into strings with a leading dot
+	MapType               reflect.Type // type of maps for structs decoded into any, map[string]any if nil
+	MaxDepth              int          // maximum nesting of initializer lists, or 0 for no limit
+	MaxBytes              int          // maximum size of the input in bytes, or 0 for no limit
+}
+
+// Unmarshal parses the data into the value pointed to by v using the options in o.
+// v must be a non-nil pointer.
+func (o UnmarshalOptions) Unmarshal(data []byte, v any) error {
+	rv := reflect.ValueOf(v)
+
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("zon: v must be a non-nil pointer")
+	}
+
+	if o.MapType != nil && !anyMapType.ConvertibleTo(o.MapType) {
+		return fmt.Errorf("zon: map type %s is not convertible from %s", o.MapType, anyMapType)
+	}
+
+	if o.MaxBytes > 0 && len(data) > o.MaxBytes {
+		return fmt.Errorf("zon: input of %d bytes exceeds the maximum of %d: %w", len(data), o.MaxBytes, ErrLimitExceeded)
+	}
+
+	return safeParseValue(newParser(data, o), rv.Elem())
+}
+
+// DecodeOption configures decoding in Unmarshal, Decode and NewDecoder.
+type DecodeOption func(o *UnmarshalOptions)
+
+// Strict makes decoding reject input that std.zon.parse rejects: missing or stray commas,
+// duplicate fields, fields mixed with tuple elements, bare identifiers, data after
+// the top-level value and null for values that are not pointers or interfaces.
+func Strict(enabled bool) DecodeOption {
+	return func(o *UnmarshalOptions) {
+		o.Strict = enabled
+	}
+}
+
+// DisallowUnknownFields makes decoding fail when the input has fields that do not match
+// any field of the struct they are decoded into. The error lists every unknown field.
+func DisallowUnknownFields(enabled bool) DecodeOption {
+	return func(o *UnmarshalOptions) {
+		o.DisallowUnknownFields = enabled
+	}
+}
+
+// UseNumber makes numbers decoded into an any value become a Number instead of
+// an int64, uint64, *big.Int or float64.
+func UseNumber(enabled bool) DecodeOption {
+	return func(o *UnmarshalOptions) {
+		o.UseNumber = enabled
+	}
+}
+
+// CaseInsensitiveFields makes field names match struct fields regardless of case,
+// preferring an exact match when there is one.
+func CaseInsensitiveFields(enabled bool) DecodeOption {
+	return func(o *UnmarshalOptions) {
+		o.CaseInsensitive = enabled
+	}
+}
+
+// EnumLiteralStrings makes enum literals decode into strings with a leading dot,
+// such as ".name", both for string values and inside any, as they did before
+// EnumLiteral was introduced.
+func EnumLiteralStrings(enabled bool) DecodeOption {
+	return func(o *UnmarshalOptions) {
+		o.EnumStrings = enabled
+	}
+}
+
+// MapType sets the type of maps created for structs decoded into an any value.
+// It must be convertible from map[string]any, such as a named map type.
+func MapType(t reflect.Type) DecodeOption {
+	return func(o *UnmarshalOptions) {
+		o.MapType = t
+	}
+}
+
+// MaxDepth limits how deeply initializer lists may be nested, with 0 meaning no limit.
+func MaxDepth(n int) DecodeOption {
+	return func(o *UnmarshalOptions) {
+		o.MaxDepth = n
+	}
+}
+
+// MaxBytes limits the size of the input in bytes, with 0 meaning no limit.
+func MaxBytes(n int) DecodeOption {
+	return func(o *UnmarshalOptions) {
+		o.MaxBytes = n
+	}
+}
+
+func unmarshalOptions(opts []DecodeOption) UnmarshalOptions {
+	var o UnmarshalOptions
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
@@ -1,26 +1,388 @@
 package zon
 
 import (
+	"encoding"
+	"errors"
 	"fmt"
+	"math/big"
 	"reflect"
+	"slices"
 	"strconv"
-	"unicode"
+	"strings"
+)
+
+var (
+	anyType    = reflect.TypeFor[any]()
+	anyMapType = reflect.TypeFor[map[string]any]()
 )
 
 type parser struct {
-	data []byte
-	pos  int
+	s    *Scanner
+	o    UnmarshalOptions
+	toks []Token // lookahead tokens, starting at head
+	head int
+
+	path        []pathElem   // ZON path of the value being parsed
+	structType  reflect.Type // struct containing the field being parsed, if any
+	structField string       // Go name of the field being parsed
+
+	unknown []UnknownField // fields without a matching struct field, if DisallowUnknownFields is set
+	missing []MissingField // required fields missing from struct literals
+	depth   int            // number of initializer lists being parsed
+	end     int            // offset just after the last consumed token
+}
+
+// pathElem is a field name or, if index is not negative, a tuple index in a ZON path.
+// Elements are only formatted when an error needs the path.
+type pathElem struct {
+	name  string
+	index int
+}
+
+func newParser(data []byte, o UnmarshalOptions) *parser {
+	return &parser{s: NewScanner(data), o: o}
+}
+
+// pushField appends the field name to the path.
+func (p *parser) pushField(name string) {
+	p.path = append(p.path, pathElem{name: name, index: -1})
+}
+
+// pushIndex appends the tuple index i to the path.
+func (p *parser) pushIndex(i int) {
+	p.path = append(p.path, pathElem{index: i})
+}
+
+// pop removes the last element of the path.
+func (p *parser) pop() {
+	p.path = p.path[:len(p.path)-1]
+}
+
+// pathString returns the path formatted like .name[1].field.
+func (p *parser) pathString() string {
+	var b strings.Builder
+
+	for _, e := range p.path {
+		if e.index >= 0 {
+			b.WriteString("[" + strconv.Itoa(e.index) + "]")
+		} else {
+			b.WriteString("." + formatIdent(e.name))
+		}
+	}
+
+	return b.String()
+}
+
+// peek returns the next non-comment token without consuming it.
+func (p *parser) peek() (Token, error) {
+	return p.peekN(0)
+}
+
+// peekN returns the non-comment token n positions ahead without consuming anything.
+func (p *parser) peekN(n int) (Token, error) {
+	for len(p.toks)-p.head <= n {
+		tok, err := p.s.Scan()
+		if err != nil {
+			return tok, err
+		}
+
+		if tok.Kind != TokenComment {
+			p.toks = append(p.toks, tok)
+		}
+	}
+
+	return p.toks[p.head+n], nil
+}
+
+// next consumes and returns the next non-comment token.
+func (p *parser) next() (Token, error) {
+	tok, err := p.peek()
+	if err != nil {
+		return tok, err
+	}
+
+	p.head++
+	p.end = tok.End()
+
+	if p.head == len(p.toks) {
+		p.toks, p.head = p.toks[:0], 0
+	}
+
+	return tok, nil
+}
+
+// errorAt returns a SyntaxError located at the byte offset pos.
+func (p *parser) errorAt(pos int, err error, format string, args ...any) error {
+	return syntaxError(pos, err, format, args...).locate(p.s.data)
+}
+
+// numberError returns a SyntaxError for a number literal at pos that could not be parsed.
+func (p *parser) numberError(pos int, err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return p.errorAt(pos, ErrOverflow, "%v", err)
+	}
+
+	return p.errorAt(pos, ErrInvalidNumber, "%v", err)
+}
+
+// typeError returns an UnmarshalTypeError for a value of the given kind at tok
+// that cannot be stored in a Go value of type t.
+func (p *parser) typeError(tok Token, kind string, t reflect.Type) error {
+	e := &UnmarshalTypeError{
+		Value:  kind,
+		Type:   t,
+		Path:   p.pathString(),
+		Offset: tok.Offset,
+	}
+
+	if p.structType != nil {
+		e.Struct, e.Field = p.structType.Name(), p.structField
+	}
+
+	e.Line, e.Column, _ = position(p.s.data, tok.Offset)
+
+	return e
+}
+
+// mismatch reports the consumed token tok, found where a value of type t was expected.
+// Tokens that cannot start a value are syntax errors, any other value is a type error.
+func (p *parser) mismatch(tok Token, t reflect.Type, expected string) error {
+	switch tok.Kind {
+	case TokenEOF, TokenRBrace, TokenEqual, TokenComma:
+		return p.s.Unexpected(tok, expected)
+	}
+
+	return p.typeError(tok, p.valueKind(tok, 0), t)
+}
+
+// valueKind describes the kind of value starting at tok,
+// where the token after tok is n tokens ahead.
+func (p *parser) valueKind(tok Token, n int) string {
+	switch tok.Kind {
+	case TokenLBrace:
+		first, err := p.peekN(n)
+		if err != nil {
+			return "tuple"
+		}
+
+		second, err := p.peekN(n + 1)
+		if err == nil && first.Kind == TokenEnumLiteral && second.Kind == TokenEqual {
+			return "struct"
+		}
+
+		return "tuple"
+	case TokenString, TokenMultilineString:
+		return "string"
+	case TokenNumber:
+		return "number " + tok.Text
+	case TokenMinus:
+		return "number"
+	case TokenIdent:
+		if tok.Text == "inf" || tok.Text == "nan" {
+			return "number"
+		}
+
+		return "identifier"
+	case TokenTrue, TokenFalse:
+		return "boolean"
+	default:
+		return tok.Kind.String()
+	}
+}
+
+// open consumes the '.{' starting a value of type t.
+func (p *parser) open(t reflect.Type) (Token, error) {
+	tok, err := p.next()
+	if err != nil {
+		return tok, err
+	}
+
+	if tok.Kind != TokenLBrace {
+		return tok, p.mismatch(tok, t, TokenLBrace.String())
+	}
+
+	return tok, nil
+}
+
+// expect consumes the next token, which must be of the given kind.
+func (p *parser) expect(kind TokenKind) (Token, error) {
+	tok, err := p.next()
+	if err != nil {
+		return tok, err
+	}
+
+	if tok.Kind != kind {
+		return tok, p.s.Unexpected(tok, kind.String())
+	}
+
+	return tok, nil
+}
+
+// parseDocument parses the top-level value into v.
+// In strict mode it must be followed by the end of the input.
+func (p *parser) parseDocument(v reflect.Value) error {
+	if err := p.parseValue(v); err != nil {
+		return err
+	}
+
+	if p.o.Strict {
+		if err := p.expectEOF(); err != nil {
+			return err
+		}
+	}
+
+	var errs []error
+
+	if len(p.unknown) > 0 {
+		errs = append(errs, &UnknownFieldsError{Fields: p.unknown})
+	}
+
+	if len(p.missing) > 0 {
+		errs = append(errs, &MissingFieldsError{Fields: p.missing})
+	}
+
+	if len(errs) == 1 {
+		return errs[0]
+	}
+
+	return errors.Join(errs...)
+}
+
+// expectEOF returns an error unless all of the input has been consumed.
+func (p *parser) expectEOF() error {
+	tok, err := p.peek()
+	if err != nil {
+		return err
+	}
+
+	if tok.Kind != TokenEOF {
+		return p.s.Unexpected(tok, TokenEOF.String())
+	}
+
+	return nil
+}
+
+// parseEntries parses the entries of the initializer list opened by the '.{' token open,
+// calling entry for each one, and consumes the closing '}'.
+// Outside strict mode, commas between entries are optional and may be repeated.
+func (p *parser) parseEntries(open Token, entry func() error) error {
+	p.depth++
+
+	defer func() { p.depth-- }()
+
+	if p.o.MaxDepth > 0 && p.depth > p.o.MaxDepth {
+		return p.errorAt(open.Offset, ErrLimitExceeded, "nesting exceeds the maximum depth of %d", p.o.MaxDepth)
+	}
+
+	for {
+		tok, err := p.peek()
+		if err != nil {
+			return err
+		}
+
+		switch tok.Kind {
+		case TokenEOF:
+			return p.s.Unexpected(tok, "'}'")
+		case TokenRBrace:
+			p.next()
+
+			return nil
+		case TokenComma:
+			if p.o.Strict {
+				return p.s.Unexpected(tok, "value or '}'")
+			}
+
+			p.next()
+
+			continue
+		}
+
+		if err := entry(); err != nil {
+			return err
+		}
+
+		if p.o.Strict {
+			if err := p.separator(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// separator consumes the ',' after an entry, unless the list ends with '}'.
+func (p *parser) separator() error {
+	tok, err := p.peek()
+	if err != nil {
+		return err
+	}
+
+	switch tok.Kind {
+	case TokenComma:
+		p.next()
+
+		return nil
+	case TokenRBrace:
+		return nil
+	default:
+		return p.s.Unexpected(tok, "',' or '}'")
+	}
+}
+
+// checkElement reports a field where a tuple element is expected in strict mode.
+func (p *parser) checkElement() error {
+	if !p.o.Strict {
+		return nil
+	}
+
+	tok, err := p.peek()
+	if err != nil || tok.Kind != TokenEnumLiteral {
+		return err
+	}
+
+	if eq, err := p.peekN(1); err == nil && eq.Kind == TokenEqual {
+		return p.errorAt(tok.Offset, ErrUnexpectedToken, "field %s in tuple", tok.Text)
+	}
+
+	return nil
+}
+
+// checkDuplicate reports a field name that is already in seen in strict mode.
+func (p *parser) checkDuplicate(seen map[string]bool, key string, tok Token) error {
+	if !p.o.Strict {
+		return nil
+	}
+
+	if seen[key] {
+		return p.errorAt(tok.Offset, ErrDuplicateField, "duplicate field %s", tok.Text)
+	}
+
+	seen[key] = true
+
+	return nil
 }
 
 func (p *parser) parseValue(v reflect.Value) error {
-	p.skipSpace()
+	return p.decode(v, nil)
+}
 
-	if p.pos >= len(p.data) {
-		return fmt.Errorf("zon: unexpected end of input")
+// decode parses the next value into v with dec, the decoder for the type v holds
+// once pointers are followed. If dec is nil, the decoder is looked up.
+func (p *parser) decode(v reflect.Value, dec decoderFunc) error {
+	tok, err := p.peek()
+	if err != nil {
+		return err
+	}
+
+	if tok.Kind == TokenEOF {
+		return p.s.Unexpected(tok, "value")
 	}
 
-	if hasPrefixAt(p.data, p.pos, "null") {
-		p.pos += 4
+	if tok.Kind == TokenNull && v.Type() != rawValueType {
+		p.next()
+
+		if p.o.Strict && v.Kind() != reflect.Pointer && v.Kind() != reflect.Interface {
+			return p.typeError(tok, "null", v.Type())
+		}
 
 		if v.CanSet() {
 			v.Set(reflect.Zero(v.Type()))
@@ -29,6 +391,10 @@ func (p *parser) parseValue(v reflect.Value) error {
 		return nil
 	}
 
+	if v.Type() == rawValueType {
+		return p.parseRaw(v)
+	}
+
 	for v.Kind() == reflect.Pointer {
 		if v.IsNil() && v.CanSet() {
 			v.Set(reflect.New(v.Type().Elem()))
@@ -37,645 +403,1254 @@ func (p *parser) parseValue(v reflect.Value) error {
 		v = v.Elem()
 	}
 
-	if v.Kind() == reflect.Interface {
-		val, err := p.parseDynamic()
-		if err != nil {
-			return err
+	if dec == nil {
+		dec = decoderFor(v.Type())
+	}
+
+	return dec(p, v)
+}
+
+// newDecoder resolves the decoder for values of type t, which is not a pointer.
+// The hooks of t are checked first, in the order Unmarshal documents.
+func newDecoder(t reflect.Type) decoderFunc {
+	dec := typeDecoder(t)
+
+	if h := hooksFor(t); t.Kind() != reflect.Interface && (h.unmarshaler || h.ptrUnmarshaler) {
+		return func(p *parser, v reflect.Value) error {
+			if u, ok := unmarshaler(v); ok {
+				return p.parseUnmarshaler(v, u)
+			}
+
+			return dec(p, v)
 		}
+	}
 
-		if v.CanSet() {
-			v.Set(val)
+	return dec
+}
+
+// typeDecoder resolves the decoder for t, leaving out Unmarshaler.
+func typeDecoder(t reflect.Type) decoderFunc {
+	switch t {
+	case bigIntType:
+		return (*parser).parseBigInt
+	case bigFloatType:
+		return (*parser).parseBigFloat
+	case numberType:
+		return (*parser).parseNumber
+	case enumLiteralType:
+		return (*parser).parseEnumLiteral
+	}
+
+	if e := lookupEnum(t); e != nil {
+		return func(p *parser, v reflect.Value) error {
+			return p.parseEnum(v, e)
 		}
+	}
 
-		return nil
+	dec := kindDecoder(t)
+
+	if t.Kind() != reflect.Interface && hooksFor(t).ptrTextUnmarshaler {
+		return func(p *parser, v reflect.Value) error {
+			if u, ok := textUnmarshaler(v); ok {
+				return p.parseText(v, u)
+			}
+
+			return dec(p, v)
+		}
 	}
 
-	switch v.Kind() {
+	return dec
+}
+
+// kindDecoder resolves the decoder for the kind of t.
+func kindDecoder(t reflect.Type) decoderFunc {
+	switch t.Kind() {
 	case reflect.Bool:
-		return p.parseBool(v)
+		return (*parser).parseBool
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return p.parseInt(v)
+		return (*parser).parseInt
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-		return p.parseUint(v)
+		return (*parser).parseUint
 	case reflect.Float32, reflect.Float64:
-		return p.parseFloat(v)
+		return (*parser).parseFloat
 	case reflect.String:
-		return p.parseString(v)
+		return (*parser).parseString
 	case reflect.Slice:
-		return p.parseSlice(v)
+		return (*parser).parseSlice
 	case reflect.Map:
-		return p.parseMap(v)
+		return (*parser).parseMap
 	case reflect.Struct:
-		return p.parseStruct(v)
+		return (*parser).parseStruct
+	case reflect.Interface:
+		if u := lookupUnion(t); u != nil {
+			return func(p *parser, v reflect.Value) error {
+				return p.parseUnion(v, u)
+			}
+		}
+
+		return (*parser).parseInterface
 	default:
-		return fmt.Errorf("zon: unsupported type %s", v.Type())
+		return func(p *parser, v reflect.Value) error {
+			return fmt.Errorf("zon: unsupported type %s", t)
+		}
+	}
+}
+
+// parseInterface parses the next value as the dynamic type it is written as,
+// and stores it in the interface v if it implements it.
+func (p *parser) parseInterface(v reflect.Value) error {
+	tok, err := p.peek()
+	if err != nil {
+		return err
+	}
+
+	var kind string
+
+	if v.NumMethod() > 0 {
+		kind = p.valueKind(tok, 1)
+	}
+
+	val, err := p.parseDynamic()
+	if err != nil {
+		return err
+	}
+
+	if val.IsValid() && !val.Type().AssignableTo(v.Type()) {
+		return p.typeError(tok, kind, v.Type())
+	}
+
+	if v.CanSet() {
+		v.Set(val)
 	}
+
+	return nil
 }
 
 func (p *parser) parseBool(v reflect.Value) error {
-	if hasPrefixAt(p.data, p.pos, "true") {
+	tok, err := p.next()
+	if err != nil {
+		return err
+	}
+
+	switch tok.Kind {
+	case TokenTrue:
 		v.SetBool(true)
+	case TokenFalse:
+		v.SetBool(false)
+	default:
+		return p.mismatch(tok, v.Type(), "boolean")
+	}
 
-		p.pos += 4
+	return nil
+}
 
-		return nil
-	} else if hasPrefixAt(p.data, p.pos, "false") {
-		v.SetBool(false)
+func (p *parser) parseInt(v reflect.Value) error {
+	tok, err := p.peek()
+	if err != nil {
+		return err
+	}
 
-		p.pos += 5
+	if tok.Kind == TokenChar {
+		return p.parseChar(v)
+	}
 
-		return nil
+	n, err := p.parseInteger(v.Type())
+	if err != nil {
+		return err
+	}
+
+	if !n.IsInt64() || v.OverflowInt(n.Int64()) {
+		return p.overflowError(tok.Text, v.Type(), tok.Offset)
 	}
-	return fmt.Errorf("zon: invalid boolean at pos %d", p.pos)
+
+	v.SetInt(n.Int64())
+
+	return nil
 }
 
-func (p *parser) parseInt(v reflect.Value) error {
-	start := p.pos
+func (p *parser) parseUint(v reflect.Value) error {
+	tok, err := p.peek()
+	if err != nil {
+		return err
+	}
+
+	if tok.Kind == TokenChar {
+		return p.parseChar(v)
+	}
+
+	n, err := p.parseInteger(v.Type())
+	if err != nil {
+		return err
+	}
+
+	if !n.IsUint64() || v.OverflowUint(n.Uint64()) {
+		return p.overflowError(tok.Text, v.Type(), tok.Offset)
+	}
+
+	v.SetUint(n.Uint64())
 
-	if p.data[p.pos] == '+' || p.data[p.pos] == '-' {
-		p.pos++
+	return nil
+}
+
+func (p *parser) parseBigInt(v reflect.Value) error {
+	n, err := p.parseInteger(v.Type())
+	if err != nil {
+		return err
+	}
+
+	v.Set(reflect.ValueOf(n).Elem())
+
+	return nil
+}
+
+// parseInteger consumes an integer literal for a value of type t and returns its value.
+func (p *parser) parseInteger(t reflect.Type) (*big.Int, error) {
+	tok, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok.Kind != TokenNumber {
+		return nil, p.mismatch(tok, t, "integer")
+	}
+
+	if isFloatLiteral(tok.Text) {
+		return nil, p.typeError(tok, p.valueKind(tok, 0), t)
 	}
 
-	if hasPrefixAt(p.data, p.pos, "0x") || hasPrefixAt(p.data, p.pos, "0X") {
-		p.pos += 2
+	neg, base, digits, err := parseIntLiteral(tok.Text)
+	if err != nil {
+		return nil, p.errorAt(tok.Offset, ErrInvalidNumber, "%v", err)
+	}
+
+	n, ok := new(big.Int).SetString(digits, base)
+	if !ok {
+		return nil, p.errorAt(tok.Offset, ErrInvalidNumber, "invalid integer literal %q", tok.Text)
+	}
+
+	if neg {
+		n.Neg(n)
+	}
+
+	return n, nil
+}
+
+func (p *parser) overflowError(lit string, t reflect.Type, pos int) error {
+	if path := p.pathString(); path != "" {
+		return p.errorAt(pos, ErrOverflow, "integer literal %s overflows %s in %s", lit, t, path)
+	}
+
+	return p.errorAt(pos, ErrOverflow, "integer literal %s overflows %s", lit, t)
+}
+
+// parseChar decodes a character literal into an integer of any kind,
+// checking that the codepoint fits in the bit size of v.
+func (p *parser) parseChar(v reflect.Value) error {
+	tok, err := p.next()
+	if err != nil {
+		return err
+	}
+
+	r, _, err := scanChar([]byte(tok.Text), 0)
+	if err != nil {
+		return err
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.OverflowInt(int64(r)) {
+			return p.errorAt(tok.Offset, ErrOverflow, "character literal %s overflows %s", tok.Text, v.Type())
+		}
+
+		v.SetInt(int64(r))
+	default:
+		if v.OverflowUint(uint64(r)) {
+			return p.errorAt(tok.Offset, ErrOverflow, "character literal %s overflows %s", tok.Text, v.Type())
+		}
+
+		v.SetUint(uint64(r))
+	}
+
+	return nil
+}
+
+func (p *parser) parseFloat(v reflect.Value) error {
+	lit, pos, err := p.floatLiteral(v.Type())
+	if err != nil {
+		return err
+	}
+
+	f, err := parseFloatLiteral(lit, v.Type().Bits())
+	if err != nil {
+		return p.numberError(pos, err)
+	}
+
+	v.SetFloat(f)
+
+	return nil
+}
+
+func (p *parser) parseBigFloat(v reflect.Value) error {
+	lit, pos, err := p.floatLiteral(v.Type())
+	if err != nil {
+		return err
+	}
+
+	target := v.Interface().(big.Float)
+
+	prec := target.Prec()
+	if prec == 0 {
+		prec = f128Prec
+	}
+
+	f, err := parseBigFloatLiteral(lit, prec)
+	if err != nil {
+		return p.numberError(pos, err)
+	}
+
+	v.Set(reflect.ValueOf(f).Elem())
+
+	return nil
+}
+
+func (p *parser) parseNumber(v reflect.Value) error {
+	lit, pos, err := p.floatLiteral(v.Type())
+	if err != nil {
+		return err
+	}
+
+	if !Number(lit).valid() {
+		return p.errorAt(pos, ErrInvalidNumber, "invalid number literal %q", lit)
+	}
+
+	v.SetString(lit)
+
+	return nil
+}
+
+// floatLiteral consumes a number literal or one of inf, -inf and nan
+// for a value of type t, returning its text and position.
+func (p *parser) floatLiteral(t reflect.Type) (string, int, error) {
+	tok, err := p.next()
+	if err != nil {
+		return "", 0, err
+	}
+
+	switch tok.Kind {
+	case TokenNumber, TokenIdent:
+		return tok.Text, tok.Offset, nil
+	case TokenMinus:
+		ident, err := p.next()
+		if err != nil {
+			return "", 0, err
+		}
+
+		if ident.Kind != TokenIdent {
+			return "", 0, p.s.Unexpected(ident, "inf")
+		}
+
+		return "-" + ident.Text, tok.Offset, nil
+	default:
+		return "", 0, p.mismatch(tok, t, "float")
+	}
+}
+
+func (p *parser) parseString(v reflect.Value) error {
+	tok, err := p.next()
+	if err != nil {
+		return err
+	}
+
+	if tok.Kind == TokenEnumLiteral && p.o.EnumStrings {
+		name, _, err := scanName([]byte(tok.Text), 1)
+		if err != nil {
+			return err
+		}
+
+		v.SetString("." + name)
+
+		return nil
+	}
+
+	if tok.Kind != TokenString && tok.Kind != TokenMultilineString {
+		return p.mismatch(tok, v.Type(), "string")
+	}
+
+	s, err := Unquote(tok.Text)
+	if err != nil {
+		return err
+	}
+
+	v.SetString(s)
+
+	return nil
+}
+
+// parseEnum parses an enum literal into v, whose type is the registered enum e.
+func (p *parser) parseEnum(v reflect.Value, e *enumType) error {
+	tok, err := p.next()
+	if err != nil {
+		return err
+	}
+
+	if tok.Kind != TokenEnumLiteral {
+		return p.mismatch(tok, v.Type(), "enum literal")
+	}
+
+	val, err := p.enumValue(e, v.Type(), tok)
+	if err != nil {
+		return err
+	}
+
+	v.Set(val)
+
+	return nil
+}
+
+// enumValue returns the value of the registered enum e of type t named by the token tok,
+// which is an enum literal or a field name.
+func (p *parser) enumValue(e *enumType, t reflect.Type, tok Token) (reflect.Value, error) {
+	name, _, err := scanName([]byte(tok.Text), 1)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	val, ok := e.values[name]
+	if !ok {
+		return reflect.Value{}, p.errorAt(tok.Offset, ErrUnknownEnumValue,
+			"unknown value %s for enum %s, expected one of %s", tok.Text, t, e.valid)
+	}
+
+	return val, nil
+}
+
+// parseUnion parses a struct literal with a single field, or an enum literal for a void variant,
+// into the interface v, whose type is the registered union u.
+func (p *parser) parseUnion(v reflect.Value, u *unionType) error {
+	open, err := p.next()
+	if err != nil {
+		return err
+	}
+
+	switch open.Kind {
+	case TokenEnumLiteral:
+		vt, err := p.variant(u, v.Type(), open)
+		if err != nil {
+			return err
+		}
+
+		if !isVoid(vt) {
+			return p.typeError(open, "enum literal", vt)
+		}
+
+		val := reflect.New(vt).Elem()
+
+		if vt.Kind() == reflect.Pointer {
+			val.Set(reflect.New(vt.Elem()))
+		}
+
+		v.Set(val)
+
+		return nil
+	case TokenLBrace:
+	default:
+		return p.mismatch(open, v.Type(), "union")
+	}
+
+	set := false
+
+	err = p.parseEntries(open, func() error {
+		tok, err := p.peek()
+		if err != nil {
+			return err
+		}
+
+		if set {
+			return p.errorAt(tok.Offset, ErrUnexpectedToken, "union %s with more than one field", v.Type())
+		}
+
+		key, err := p.parseKey()
+		if err != nil {
+			return err
+		}
+
+		vt, err := p.variant(u, v.Type(), tok)
+		if err != nil {
+			return err
+		}
+
+		val := reflect.New(vt).Elem()
+
+		p.pushField(key)
+
+		err = p.parseValue(val)
+
+		p.pop()
+
+		if err != nil {
+			return err
+		}
+
+		v.Set(val)
+
+		set = true
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !set {
+		return p.errorAt(open.Offset, ErrUnexpectedToken, "union %s without a field", v.Type())
+	}
+
+	return nil
+}
+
+// variant returns the type of the variant of the union u of type t named by the token tok,
+// which is an enum literal or a field name.
+func (p *parser) variant(u *unionType, t reflect.Type, tok Token) (reflect.Type, error) {
+	tag, _, err := scanName([]byte(tok.Text), 1)
+	if err != nil {
+		return nil, err
+	}
+
+	vt, ok := u.types[tag]
+	if !ok {
+		return nil, p.errorAt(tok.Offset, ErrUnknownVariant,
+			"unknown variant %s for union %s, expected one of %s", tok.Text, t, u.valid)
+	}
+
+	return vt, nil
+}
+
+// parseEnumLiteral parses an enum literal such as .name into the EnumLiteral v.
+func (p *parser) parseEnumLiteral(v reflect.Value) error {
+	tok, err := p.next()
+	if err != nil {
+		return err
+	}
+
+	if tok.Kind != TokenEnumLiteral {
+		return p.mismatch(tok, v.Type(), "enum literal")
+	}
+
+	name, _, err := scanName([]byte(tok.Text), 1)
+	if err != nil {
+		return err
+	}
+
+	v.SetString(name)
+
+	return nil
+}
+
+func (p *parser) parseSlice(v reflect.Value) error {
+	open, err := p.open(v.Type())
+	if err != nil {
+		return err
+	}
+
+	// Always start with an empty slice (non-nil).
+	slice := reflect.MakeSlice(v.Type(), 0, 0)
+
+	dec := decoderFor(indirect(v.Type().Elem()))
+
+	err = p.parseEntries(open, func() error {
+		if err := p.checkElement(); err != nil {
+			return err
+		}
+
+		elem := reflect.New(v.Type().Elem()).Elem()
+
+		p.pushIndex(slice.Len())
+
+		err := p.decode(elem, dec)
+
+		p.pop()
+
+		if err != nil {
+			return err
+		}
+
+		slice = reflect.Append(slice, elem)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Ensure non-nil slice is set
+	v.Set(slice)
+
+	return nil
+}
+
+// parseKey consumes a field name followed by '=', returning the name.
+func (p *parser) parseKey() (string, error) {
+	tok, err := p.next()
+	if err != nil {
+		return "", err
+	}
+
+	if tok.Kind != TokenEnumLiteral {
+		return "", p.s.Unexpected(tok, "field name")
+	}
+
+	key, _, err := scanName([]byte(tok.Text), 1)
+	if err != nil {
+		return "", err
+	}
+
+	eq, err := p.next()
+	if err != nil {
+		return "", err
+	}
+
+	if eq.Kind != TokenEqual {
+		return "", p.s.Unexpected(eq, "'=' after field name")
+	}
+
+	return key, nil
+}
+
+func (p *parser) parseMap(v reflect.Value) error {
+	open, err := p.open(v.Type())
+	if err != nil {
+		return err
+	}
+
+	v.Set(reflect.MakeMap(v.Type()))
+
+	seen := map[string]bool{}
+
+	dec := decoderFor(indirect(v.Type().Elem()))
+
+	return p.parseEntries(open, func() error {
+		tok, err := p.peek()
+		if err != nil {
+			return err
+		}
+
+		key, err := p.parseKey()
+		if err != nil {
+			return err
+		}
+
+		if err := p.checkDuplicate(seen, key, tok); err != nil {
+			return err
+		}
+
+		kv, err := p.mapKey(v.Type().Key(), key, tok)
+		if err != nil {
+			return err
+		}
+
+		val := reflect.New(v.Type().Elem()).Elem()
+
+		p.pushField(key)
+
+		err = p.decode(val, dec)
+
+		p.pop()
+
+		if err != nil {
+			return err
+		}
+
+		v.SetMapIndex(kv, val)
+
+		return nil
+	})
+}
+
+// mapKey converts the field name key at tok to a map key of type t.
+// Integer keys are written in decimal, as in .@"1", registered enum keys by name,
+// and keys implementing encoding.TextUnmarshaler by their text.
+func (p *parser) mapKey(t reflect.Type, key string, tok Token) (reflect.Value, error) {
+	if e := lookupEnum(t); e != nil {
+		return p.enumValue(e, t, tok)
+	}
+
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		kv := reflect.New(t)
+
+		if err := kv.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(key)); err != nil {
+			p.pushField(key)
+			defer p.pop()
+
+			return reflect.Value{}, p.methodError(tok, "UnmarshalText", t, err)
+		}
+
+		return kv.Elem(), nil
+	}
+
+	kv := reflect.New(t).Elem()
+
+	switch t.Kind() {
+	case reflect.String:
+		kv.SetString(key)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(key, 10, t.Bits())
+		if err != nil {
+			return kv, p.typeError(tok, "field "+tok.Text, t)
+		}
 
-		for p.pos < len(p.data) && isHexDigit(p.data[p.pos]) {
-			p.pos++
+		kv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(key, 10, t.Bits())
+		if err != nil {
+			return kv, p.typeError(tok, "field "+tok.Text, t)
 		}
 
-		if v.CanSet() {
-			v.SetString(string(p.data[start:p.pos]))
+		kv.SetUint(n)
+	case reflect.Interface:
+		if !reflect.TypeFor[string]().AssignableTo(t) {
+			return kv, p.typeError(tok, "field "+tok.Text, t)
 		}
 
-		return nil
+		kv.Set(reflect.ValueOf(key))
+	default:
+		return kv, fmt.Errorf("zon: unsupported map key type %s", t)
 	}
 
-	for p.pos < len(p.data) && unicode.IsDigit(rune(p.data[p.pos])) {
-		p.pos++
-	}
+	return kv, nil
+}
 
-	val, err := strconv.ParseInt(string(p.data[start:p.pos]), 10, v.Type().Bits())
+func (p *parser) parseStruct(v reflect.Value) error {
+	open, err := p.open(v.Type())
 	if err != nil {
-		return fmt.Errorf("zon: invalid int literal at pos %d: %w", start, err)
+		return err
 	}
 
-	v.SetInt(val)
+	t := v.Type()
 
-	return nil
-}
+	sp := planFor(t)
 
-func (p *parser) parseUint(v reflect.Value) error {
-	start := p.pos
+	var raw []string
 
-	if hasPrefixAt(p.data, p.pos, "0x") || hasPrefixAt(p.data, p.pos, "0X") {
-		p.pos += 2
+	seen, set := map[string]bool{}, map[string]bool{}
 
-		for p.pos < len(p.data) && isHexDigit(p.data[p.pos]) {
-			p.pos++
+	err = p.parseEntries(open, func() error {
+		tok, err := p.peek()
+		if err != nil {
+			return err
 		}
 
-		if v.CanSet() {
-			v.SetString(string(p.data[start:p.pos]))
+		key, err := p.parseKey()
+		if err != nil {
+			return err
 		}
 
-		return nil
-	}
-
-	for p.pos < len(p.data) && unicode.IsDigit(rune(p.data[p.pos])) {
-		p.pos++
-	}
-
-	val, err := strconv.ParseUint(string(p.data[start:p.pos]), 10, v.Type().Bits())
-	if err != nil {
-		return fmt.Errorf("zon: invalid uint literal at pos %d: %w", start, err)
-	}
+		if err := p.checkDuplicate(seen, key, tok); err != nil {
+			return err
+		}
 
-	v.SetUint(val)
+		f := sp.lookup(key, p.o.CaseInsensitive)
 
-	return nil
-}
+		if f == nil {
+			if p.o.DisallowUnknownFields && sp.rest == nil {
+				p.unknownField(t, sp, key, tok)
+			}
 
-func (p *parser) parseFloat(v reflect.Value) error {
-	start := p.pos
+			p.pushField(key)
 
-	for p.pos < len(p.data) && (unicode.IsDigit(rune(p.data[p.pos])) ||
-		containsRune("+-eE.", rune(p.data[p.pos]))) {
-		p.pos++
-	}
+			if sp.rest != nil {
+				var rv reflect.Value
 
-	numStr := string(p.data[start:p.pos])
+				if rv, err = fieldForDecode(v, sp.rest.index); err == nil {
+					err = p.parseRest(rv, key, &raw)
+				}
+			} else {
+				err = p.skipValue()
+			}
 
-	if numStr == "" {
-		return fmt.Errorf("zon: invalid float literal at pos %d", start)
-	}
+			p.pop()
 
-	f, err := strconv.ParseFloat(numStr, v.Type().Bits())
-	if err != nil {
-		return fmt.Errorf("zon: invalid float literal at pos %d: %w", start, err)
-	}
+			return err
+		}
 
-	v.SetFloat(f)
+		set[f.name] = true
 
-	return nil
-}
+		outerType, outerField := p.structType, p.structField
 
-func (p *parser) parseString(v reflect.Value) error {
-	if p.data[p.pos] != '"' {
-		return fmt.Errorf("zon: expected '\"' at pos %d", p.pos)
-	}
+		p.pushField(key)
+		p.structType, p.structField = t, f.goName
 
-	p.pos++
+		fv, err := fieldForDecode(v, f.index)
+		if err != nil {
+			return err
+		}
 
-	start := p.pos
+		if f.quoted {
+			err = p.parseQuoted(fv)
+		} else {
+			err = p.decode(fv, f.decode)
+		}
 
-	for p.pos < len(p.data) && p.data[p.pos] != '"' {
-		p.pos++
-	}
+		p.pop()
+		p.structType, p.structField = outerType, outerField
 
-	if p.pos >= len(p.data) {
-		return fmt.Errorf("zon: unterminated string")
+		return err
+	})
+	if err != nil {
+		return err
 	}
 
-	v.SetString(string(p.data[start:p.pos]))
-
-	p.pos++
-
-	return nil
-}
+	if sp.rest != nil && raw != nil {
+		rv, err := fieldForDecode(v, sp.rest.index)
+		if err != nil {
+			return err
+		}
 
-func (p *parser) parseSlice(v reflect.Value) error {
-	if !hasPrefixAt(p.data, p.pos, ".{") {
-		return fmt.Errorf("zon: expected '.{' at pos %d", p.pos)
+		rv.SetBytes([]byte(".{ " + strings.Join(raw, ", ") + " }"))
 	}
 
-	p.pos += 2
-
-	// Always start with an empty slice (non-nil).
-	slice := reflect.MakeSlice(v.Type(), 0, 0)
-
-	for {
-		p.skipSpace()
-		if p.pos >= len(p.data) {
-			return fmt.Errorf("zon: unexpected end of slice")
+	for _, f := range sp.fields {
+		if set[f.name] || f.rest {
+			continue
 		}
 
-		if p.data[p.pos] == '}' {
-			p.pos++
-			break
-		}
+		if f.required {
+			p.missingField(t, f, open)
 
-		if p.data[p.pos] == ',' {
-			p.pos++
 			continue
 		}
 
-		elem := reflect.New(v.Type().Elem()).Elem()
-		if err := p.parseValue(elem); err != nil {
+		fv, err := fieldForDecode(v, f.index)
+		if err != nil {
 			return err
 		}
 
-		slice = reflect.Append(slice, elem)
+		if err := applyDefault(fv, f); err != nil {
+			return err
+		}
 	}
 
-	// Ensure non-nil slice is set
-	v.Set(slice)
-
 	return nil
 }
 
-func (p *parser) parseMap(v reflect.Value) error {
-	if !hasPrefixAt(p.data, p.pos, ".{") {
-		return fmt.Errorf("zon: expected '.{' at pos %d", p.pos)
+// missingField records the required field f, which is missing from the struct literal
+// of type t opened by the token open.
+func (p *parser) missingField(t reflect.Type, f field, open Token) {
+	m := MissingField{
+		Name:   f.name,
+		Path:   p.pathString(),
+		Struct: t.Name(),
+		Offset: open.Offset,
 	}
 
-	p.pos += 2
-
-	v.Set(reflect.MakeMap(v.Type()))
+	m.Line, m.Column, _ = position(p.s.data, open.Offset)
 
-	for {
-		p.skipSpace()
+	p.missing = append(p.missing, m)
+}
 
-		if p.pos >= len(p.data) {
-			return fmt.Errorf("zon: unexpected end of map")
+// applyDefault sets the field value v, which is missing from the input, to the default of f.
+// Fields without a default that hold a struct get the defaults of their own fields.
+func applyDefault(v reflect.Value, f field) error {
+	if f.hasDefault {
+		if err := newParser([]byte(f.def), UnmarshalOptions{Strict: true}).parseDocument(v); err != nil {
+			return fmt.Errorf("zon: invalid default for field %s: %w", f.goName, err)
 		}
 
-		if p.data[p.pos] == '}' {
-			p.pos++
-
-			break
-		}
+		return nil
+	}
 
-		if p.data[p.pos] == ',' {
-			p.pos++
+	if !hasDefaults(v.Type()) {
+		return nil
+	}
 
+	for _, f := range planFor(v.Type()).fields {
+		if f.rest || !f.hasDefault && !hasDefaults(v.Type().FieldByIndex(f.index).Type) {
 			continue
 		}
 
-		if p.data[p.pos] != '.' {
-			return fmt.Errorf("zon: expected '.' for map key at pos %d", p.pos)
+		fv, err := fieldForDecode(v, f.index)
+		if err != nil {
+			return err
 		}
 
-		p.pos++
-
-		start := p.pos
-
-		for p.pos < len(p.data) && (unicode.IsLetter(rune(p.data[p.pos])) || unicode.IsDigit(rune(p.data[p.pos])) || p.data[p.pos] == '_') {
-			p.pos++
+		if err := applyDefault(fv, f); err != nil {
+			return err
 		}
+	}
+
+	return nil
+}
 
-		key := string(p.data[start:p.pos])
+// parseRaw stores the source of the next value in the RawValue v.
+func (p *parser) parseRaw(v reflect.Value) error {
+	raw, err := p.rawValue()
+	if err != nil {
+		return err
+	}
 
-		p.skipSpace()
+	v.SetBytes(raw)
 
-		if p.pos >= len(p.data) || p.data[p.pos] != '=' {
-			return fmt.Errorf("zon: expected '=' after key at pos %d", p.pos)
-		}
+	return nil
+}
 
-		p.pos++
+// rawValue consumes the next value and returns a copy of its source.
+func (p *parser) rawValue() ([]byte, error) {
+	tok, err := p.peek()
+	if err != nil {
+		return nil, err
+	}
 
-		p.skipSpace()
+	if err := p.skipValue(); err != nil {
+		return nil, err
+	}
 
-		val := reflect.New(v.Type().Elem()).Elem()
+	return slices.Clone(p.s.data[tok.Offset:p.end]), nil
+}
 
-		if err := p.parseValue(val); err != nil {
+// parseRest stores the field key, which has no matching struct field, in the rest field v.
+func (p *parser) parseRest(v reflect.Value, key string, raw *[]string) error {
+	if v.Type() == rawValueType {
+		val, err := p.rawValue()
+		if err != nil {
 			return err
 		}
 
-		v.SetMapIndex(reflect.ValueOf(key), val)
-	}
+		entry := "." + formatIdent(key) + " = " + string(val)
 
-	return nil
-}
+		// A multiline string runs to the end of its line, so the separator goes on the next one.
+		if endsInMultiline(val) {
+			entry += "\n"
+		}
 
-func (p *parser) parseStruct(v reflect.Value) error {
-	if !hasPrefixAt(p.data, p.pos, ".{") {
-		return fmt.Errorf("zon: expected '.{' at pos %d", p.pos)
-	}
+		*raw = append(*raw, entry)
 
-	p.pos += 2
+		return nil
+	}
 
-	t := v.Type()
+	if v.IsNil() {
+		v.Set(reflect.MakeMap(v.Type()))
+	}
 
-	for {
-		p.skipSpace()
+	val := reflect.New(v.Type().Elem()).Elem()
 
-		if p.pos >= len(p.data) {
-			return fmt.Errorf("zon: unexpected end of struct")
-		}
+	if err := p.parseValue(val); err != nil {
+		return err
+	}
 
-		if p.data[p.pos] == '}' {
-			p.pos++
+	v.SetMapIndex(reflect.ValueOf(key).Convert(v.Type().Key()), val)
 
-			break
-		}
+	return nil
+}
 
-		if p.data[p.pos] == ',' {
-			p.pos++
+// parseQuoted parses a field with the string tag option, where v holds a number or a bool
+// that is written inside a string literal. Values outside of a string are accepted as well.
+func (p *parser) parseQuoted(v reflect.Value) error {
+	tok, err := p.peek()
+	if err != nil {
+		return err
+	}
 
-			continue
-		}
+	if tok.Kind != TokenString {
+		return p.parseValue(v)
+	}
 
-		if p.data[p.pos] != '.' {
-			return fmt.Errorf("zon: expected '.' at pos %d", p.pos)
-		}
+	p.next()
 
-		p.pos++
+	s, err := Unquote(tok.Text)
+	if err != nil {
+		return err
+	}
 
-		start := p.pos
+	if err := newParser([]byte(s), UnmarshalOptions{Strict: true}).parseDocument(v); err != nil {
+		sentinel := ErrInvalidString
 
-		for p.pos < len(p.data) && (unicode.IsLetter(rune(p.data[p.pos])) || unicode.IsDigit(rune(p.data[p.pos])) || p.data[p.pos] == '_') {
-			p.pos++
+		var se *SyntaxError
+		if errors.As(err, &se) && se.Err == ErrOverflow {
+			sentinel = ErrOverflow
 		}
 
-		key := string(p.data[start:p.pos])
+		return p.errorAt(tok.Offset, sentinel, "string %s is not a valid %s", tok.Text, v.Type())
+	}
 
-		p.skipSpace()
+	return nil
+}
 
-		if p.pos >= len(p.data) || p.data[p.pos] != '=' {
-			return fmt.Errorf("zon: expected '=' after key at pos %d", p.pos)
-		}
+// unknownField records the field key at tok, which has no matching field in the struct type t.
+func (p *parser) unknownField(t reflect.Type, sp *structPlan, key string, tok Token) {
+	u := UnknownField{
+		Path:       p.pathString() + "." + formatIdent(key),
+		Struct:     t.Name(),
+		Suggestion: suggest(key, sp.names()),
+		Offset:     tok.Offset,
+	}
 
-		p.pos++
+	u.Line, u.Column, _ = position(p.s.data, tok.Offset)
 
-		p.skipSpace()
+	p.unknown = append(p.unknown, u)
+}
 
-		var field reflect.Value
+// skipValue consumes the next value with the same checks as parseDynamic,
+// without building the maps and slices of initializer lists.
+func (p *parser) skipValue() error {
+	tok, err := p.peek()
+	if err != nil {
+		return err
+	}
 
-		found := false
+	if tok.Kind != TokenLBrace {
+		_, err := p.parseDynamic()
 
-		for i := 0; i < t.NumField(); i++ {
-			f := t.Field(i)
+		return err
+	}
 
-			if f.PkgPath != "" {
-				continue
-			}
+	open, _ := p.next()
 
-			name := f.Tag.Get("zon")
+	first, err := p.peek()
+	if err != nil {
+		return err
+	}
 
-			if name == "" {
-				name = f.Name
-			}
+	second, err := p.peekN(1)
+	if err != nil && first.Kind == TokenEnumLiteral {
+		return err
+	}
 
-			if name == key {
-				field = v.Field(i)
-				found = true
+	if first.Kind == TokenEnumLiteral && second.Kind == TokenEqual {
+		seen := map[string]bool{}
 
-				break
+		return p.parseEntries(open, func() error {
+			tok, err := p.peek()
+			if err != nil {
+				return err
 			}
-		}
 
-		if !found {
-			skip := reflect.New(reflect.TypeOf(new(any)).Elem()).Elem()
+			key, err := p.parseKey()
+			if err != nil {
+				return err
+			}
 
-			_ = p.parseValue(skip)
+			if err := p.checkDuplicate(seen, key, tok); err != nil {
+				return err
+			}
 
-			continue
-		}
+			return p.skipValue()
+		})
+	}
 
-		if err := p.parseValue(field); err != nil {
+	return p.parseEntries(open, func() error {
+		if err := p.checkElement(); err != nil {
 			return err
 		}
-	}
 
-	return nil
+		return p.skipValue()
+	})
 }
 
 func (p *parser) parseDynamic() (reflect.Value, error) {
-	p.skipSpace()
-
-	if p.pos >= len(p.data) {
-		return reflect.Value{}, fmt.Errorf("zon: unexpected end of input")
+	tok, err := p.peek()
+	if err != nil {
+		return reflect.Value{}, err
 	}
 
-	c := p.data[p.pos]
-
-	switch c {
-	case '"':
-		var s string
+	switch tok.Kind {
+	case TokenEOF:
+		return reflect.Value{}, p.s.Unexpected(tok, "value")
+	case TokenString, TokenMultilineString:
+		p.next()
 
-		if err := p.parseStringDynamic(&s); err != nil {
+		s, err := Unquote(tok.Text)
+		if err != nil {
 			return reflect.Value{}, err
 		}
 
 		return reflect.ValueOf(s), nil
-	case '.':
-		if p.pos+1 < len(p.data) && p.data[p.pos+1] == '{' {
-			return p.parseDynamicMapOrSlice()
-		}
+	case TokenChar:
+		p.next()
 
-		start := p.pos + 1
+		r, _, err := scanChar([]byte(tok.Text), 0)
+		if err != nil {
+			return reflect.Value{}, err
+		}
 
-		p.pos++
+		return reflect.ValueOf(int64(r)), nil
+	case TokenLBrace:
+		return p.parseDynamicMapOrSlice()
+	case TokenEnumLiteral:
+		p.next()
 
-		for p.pos < len(p.data) && (unicode.IsLetter(rune(p.data[p.pos])) || unicode.IsDigit(rune(p.data[p.pos])) || p.data[p.pos] == '_') {
-			p.pos++
+		ident, _, err := scanName([]byte(tok.Text), 1)
+		if err != nil {
+			return reflect.Value{}, err
 		}
 
-		ident := string(p.data[start:p.pos])
-		if ident == "" {
-			return reflect.Value{}, fmt.Errorf("zon: invalid identifier at pos %d", start)
+		if p.o.EnumStrings {
+			return reflect.ValueOf("." + ident), nil
 		}
 
-		return reflect.ValueOf("." + ident), nil
-	default:
-		if isDigit(c) || c == '+' || c == '-' {
-			return p.parseNumberDynamic()
-		} else if hasPrefixAt(p.data, p.pos, "true") {
-			p.pos += 4
-
-			return reflect.ValueOf(true), nil
-		} else if hasPrefixAt(p.data, p.pos, "false") {
-			p.pos += 5
-
-			return reflect.ValueOf(false), nil
-		} else {
-			start := p.pos
-
-			for p.pos < len(p.data) && (unicode.IsLetter(rune(p.data[p.pos])) || unicode.IsDigit(rune(p.data[p.pos])) || p.data[p.pos] == '_') {
-				p.pos++
+		return reflect.ValueOf(EnumLiteral(ident)), nil
+	case TokenNumber:
+		return p.parseNumberDynamic()
+	case TokenMinus, TokenIdent:
+		if tok.Kind == TokenIdent && tok.Text != "inf" && tok.Text != "nan" {
+			if p.o.Strict {
+				return reflect.Value{}, p.s.Unexpected(tok, "value")
 			}
 
-			ident := string(p.data[start:p.pos])
-			if ident == "" {
-				return reflect.Value{}, fmt.Errorf("zon: unexpected token at pos %d", start)
-			}
+			p.next()
 
-			return reflect.ValueOf(ident), nil
+			return reflect.ValueOf(tok.Text), nil
 		}
-	}
-}
-
-func (p *parser) parseNumberDynamic() (reflect.Value, error) {
-	start := p.pos
-
-	if p.data[p.pos] == '+' || p.data[p.pos] == '-' {
-		p.pos++
-	}
 
-	if hasPrefixAt(p.data, p.pos, "0x") || hasPrefixAt(p.data, p.pos, "0X") {
-		p.pos += 2
+		lit, pos, err := p.floatLiteral(anyType)
+		if err != nil {
+			return reflect.Value{}, err
+		}
 
-		for p.pos < len(p.data) && isHexDigit(p.data[p.pos]) {
-			p.pos++
+		if p.o.UseNumber && Number(lit).valid() {
+			return reflect.ValueOf(Number(lit)), nil
 		}
 
-		hexStr := string(p.data[start:p.pos])
+		f, err := parseFloatLiteral(lit, 64)
+		if err != nil {
+			return reflect.Value{}, p.numberError(pos, err)
+		}
 
-		return reflect.ValueOf(hexStr), nil
-	}
+		return reflect.ValueOf(f), nil
+	case TokenTrue:
+		p.next()
 
-	dotSeen := false
+		return reflect.ValueOf(true), nil
+	case TokenFalse:
+		p.next()
 
-	for p.pos < len(p.data) && (unicode.IsDigit(rune(p.data[p.pos])) || p.data[p.pos] == '.' || p.data[p.pos] == 'e' || p.data[p.pos] == 'E' || p.data[p.pos] == '+' || p.data[p.pos] == '-') {
-		if p.data[p.pos] == '.' {
-			if dotSeen {
-				break
-			}
+		return reflect.ValueOf(false), nil
+	case TokenNull:
+		p.next()
 
-			dotSeen = true
-		}
+		return reflect.Zero(anyType), nil
+	default:
+		return reflect.Value{}, p.s.Unexpected(tok, "value")
+	}
+}
 
-		p.pos++
+func (p *parser) parseNumberDynamic() (reflect.Value, error) {
+	tok, err := p.peek()
+	if err != nil {
+		return reflect.Value{}, err
 	}
 
-	numStr := string(p.data[start:p.pos])
-	if numStr == "" {
-		return reflect.Value{}, fmt.Errorf("zon: invalid number at pos %d", start)
+	if p.o.UseNumber {
+		p.next()
+
+		return reflect.ValueOf(Number(tok.Text)), nil
 	}
 
-	if dotSeen || containsRune(numStr, 'e') || containsRune(numStr, 'E') {
-		f, err := strconv.ParseFloat(numStr, 64)
+	if isFloatLiteral(tok.Text) {
+		p.next()
+
+		f, err := parseFloatLiteral(tok.Text, 64)
 		if err != nil {
-			return reflect.Value{}, fmt.Errorf("zon: invalid float literal at pos %d: %w", start, err)
+			return reflect.Value{}, p.numberError(tok.Offset, err)
 		}
 
 		return reflect.ValueOf(f), nil
 	}
 
-	i, err := strconv.ParseInt(numStr, 10, 64)
+	n, err := p.parseInteger(anyType)
 	if err != nil {
-		return reflect.Value{}, fmt.Errorf("zon: invalid int literal at pos %d: %w", start, err)
+		return reflect.Value{}, err
 	}
 
-	return reflect.ValueOf(i), nil
+	switch {
+	case n.IsInt64():
+		return reflect.ValueOf(n.Int64()), nil
+	case n.IsUint64():
+		return reflect.ValueOf(n.Uint64()), nil
+	default:
+		return reflect.ValueOf(n), nil
+	}
 }
 
-func (p *parser) parseStringDynamic(out *string) error {
-	if p.data[p.pos] != '"' {
-		return fmt.Errorf("zon: expected '\"' at pos %d", p.pos)
+func (p *parser) parseDynamicMapOrSlice() (reflect.Value, error) {
+	open, err := p.expect(TokenLBrace)
+	if err != nil {
+		return reflect.Value{}, err
 	}
 
-	p.pos++
-	start := p.pos
-
-	for p.pos < len(p.data) && p.data[p.pos] != '"' {
-		p.pos++
+	first, err := p.peek()
+	if err != nil {
+		return reflect.Value{}, err
 	}
 
-	if p.pos >= len(p.data) {
-		return fmt.Errorf("zon: unterminated string")
+	second, err := p.peekN(1)
+	if err != nil && first.Kind == TokenEnumLiteral {
+		return reflect.Value{}, err
 	}
 
-	*out = string(p.data[start:p.pos])
-
-	p.pos++
-
-	return nil
-}
-
-func (p *parser) parseDynamicMapOrSlice() (reflect.Value, error) {
-	p.pos += 2
-
-	p.skipSpace()
-
-	isMap := false
-
-	if p.pos < len(p.data) && p.data[p.pos] == '.' {
-		isMap = true
-	}
+	isMap := first.Kind == TokenEnumLiteral && second.Kind == TokenEqual
 
 	if isMap {
 		m := make(map[string]any)
-		for {
-			p.skipSpace()
-
-			if p.pos >= len(p.data) {
-				return reflect.Value{}, fmt.Errorf("zon: unexpected end of map")
-			}
-
-			if p.data[p.pos] == '}' {
-				p.pos++
 
-				break
-			}
-
-			if p.data[p.pos] == ',' {
-				p.pos++
-
-				continue
-			}
+		seen := map[string]bool{}
 
-			if p.data[p.pos] != '.' {
-				return reflect.Value{}, fmt.Errorf("zon: expected '.' for map key at pos %d", p.pos)
+		err := p.parseEntries(open, func() error {
+			tok, err := p.peek()
+			if err != nil {
+				return err
 			}
 
-			p.pos++
-
-			start := p.pos
-
-			for p.pos < len(p.data) && (unicode.IsLetter(rune(p.data[p.pos])) || unicode.IsDigit(rune(p.data[p.pos])) || p.data[p.pos] == '_') {
-				p.pos++
+			key, err := p.parseKey()
+			if err != nil {
+				return err
 			}
 
-			key := string(p.data[start:p.pos])
-
-			p.skipSpace()
-
-			if p.pos >= len(p.data) || p.data[p.pos] != '=' {
-				return reflect.Value{}, fmt.Errorf("zon: expected '=' after key at pos %d", p.pos)
+			if err := p.checkDuplicate(seen, key, tok); err != nil {
+				return err
 			}
 
-			p.pos++
-
-			p.skipSpace()
-
 			val, err := p.parseDynamic()
 			if err != nil {
-				return reflect.Value{}, err
+				return err
 			}
 
 			m[key] = val.Interface()
+
+			return nil
+		})
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		if p.o.MapType != nil {
+			return reflect.ValueOf(m).Convert(p.o.MapType), nil
 		}
 
 		return reflect.ValueOf(m), nil
 	}
 
-	var arr []any
-
-	for {
-		p.skipSpace()
-
-		if p.pos >= len(p.data) {
-			return reflect.Value{}, fmt.Errorf("zon: unexpected end of slice")
-		}
-
-		if p.data[p.pos] == '}' {
-			p.pos++
-			break
-		}
+	arr := []any{}
 
-		if p.data[p.pos] == ',' {
-			p.pos++
-			continue
+	err = p.parseEntries(open, func() error {
+		if err := p.checkElement(); err != nil {
+			return err
 		}
 
 		elem, err := p.parseDynamic()
 		if err != nil {
-			return reflect.Value{}, err
+			return err
 		}
 
 		arr = append(arr, elem.Interface())
-	}
 
-	if arr == nil {
-		arr = []any{}
+		return nil
+	})
+	if err != nil {
+		return reflect.Value{}, err
 	}
 
 	return reflect.ValueOf(arr), nil
 }
 
-func (p *parser) skipSpace() {
-	for p.pos < len(p.data) {
-		if unicode.IsSpace(rune(p.data[p.pos])) {
-			p.pos++
-
-			continue
-		}
-
-		if p.data[p.pos] == '/' && p.pos+1 < len(p.data) && p.data[p.pos+1] == '/' {
-			p.pos += 2
-
-			for p.pos < len(p.data) && p.data[p.pos] != '\n' {
-				p.pos++
-			}
-
-			continue
-		}
-
-		break
-	}
-}
-
-func containsRune(s string, r rune) bool {
-	for _, c := range s {
-		if c == r {
-			return true
-		}
-	}
-
-	return false
-}
-
 func hasPrefixAt(data []byte, pos int, prefix string) bool {
 	if pos+len(prefix) > len(data) {
 		return false
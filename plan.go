@@ -0,0 +1,196 @@
+package zon
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// structPlan is the compiled plan for encoding and decoding a struct type.
+type structPlan struct {
+	fields      []field
+	byName      map[string]int // index in fields by name
+	byFold      map[string]int // index in fields by lower case name, for CaseInsensitiveFields
+	rest        *field         // field with the rest tag option, if any
+	hasDefaults bool           // fields have defaults, directly or in fields holding structs
+}
+
+var structPlans sync.Map // map[reflect.Type]*structPlan
+
+// planFor returns the plan for the struct type t, compiling it on first use.
+// It is safe for concurrent use.
+func planFor(t reflect.Type) *structPlan {
+	if sp, ok := structPlans.Load(t); ok {
+		return sp.(*structPlan)
+	}
+
+	fields := typeFields(t)
+
+	sp := &structPlan{
+		fields: fields,
+		byName: make(map[string]int, len(fields)),
+		byFold: make(map[string]int, len(fields)),
+	}
+
+	for i := range fields {
+		f := &fields[i]
+
+		ft := t.FieldByIndex(f.index).Type
+
+		f.prefix = "." + formatIdent(f.name) + " = "
+		f.encode = encoderFor(ft)
+		f.decode = decoderFor(indirect(ft))
+
+		if f.rest {
+			if sp.rest == nil {
+				sp.rest = f
+			}
+
+			continue
+		}
+
+		sp.byName[f.name] = i
+
+		if _, ok := sp.byFold[strings.ToLower(f.name)]; !ok {
+			sp.byFold[strings.ToLower(f.name)] = i
+		}
+
+		if f.hasDefault || hasDefaults(ft) {
+			sp.hasDefaults = true
+		}
+	}
+
+	actual, _ := structPlans.LoadOrStore(t, sp)
+
+	return actual.(*structPlan)
+}
+
+// lookup returns the field named key, or nil if there is none.
+// With fold set, a field whose name matches key regardless of case is returned
+// if no name matches exactly. The rest field is never returned.
+func (sp *structPlan) lookup(key string, fold bool) *field {
+	if i, ok := sp.byName[key]; ok {
+		return &sp.fields[i]
+	}
+
+	if fold {
+		if i, ok := sp.byFold[strings.ToLower(key)]; ok {
+			return &sp.fields[i]
+		}
+	}
+
+	return nil
+}
+
+// names returns the names of the fields, leaving out the rest field.
+func (sp *structPlan) names() []string {
+	names := make([]string, 0, len(sp.fields))
+
+	for _, f := range sp.fields {
+		if !f.rest {
+			names = append(names, f.name)
+		}
+	}
+
+	return names
+}
+
+// encoderFunc writes v, a value of the type it was resolved for, at level l.
+type encoderFunc func(v reflect.Value, b *bytes.Buffer, o Options, l int) error
+
+// decoderFunc parses the next value into v, a value of the type it was resolved for.
+type decoderFunc func(p *parser, v reflect.Value) error
+
+var (
+	encoders sync.Map // map[reflect.Type]encoderFunc
+	decoders sync.Map // map[reflect.Type]decoderFunc
+)
+
+// encoderFor returns the encoder for values of type t, resolving it on first use.
+// It is safe for concurrent use.
+func encoderFor(t reflect.Type) encoderFunc {
+	if enc, ok := encoders.Load(t); ok {
+		return enc.(encoderFunc)
+	}
+
+	actual, _ := encoders.LoadOrStore(t, newEncoder(t))
+
+	return actual.(encoderFunc)
+}
+
+// decoderFor returns the decoder for values of type t, which is not a pointer,
+// resolving it on first use. It is safe for concurrent use.
+func decoderFor(t reflect.Type) decoderFunc {
+	if dec, ok := decoders.Load(t); ok {
+		return dec.(decoderFunc)
+	}
+
+	actual, _ := decoders.LoadOrStore(t, newDecoder(t))
+
+	return actual.(decoderFunc)
+}
+
+// resetCodecs drops the resolved encoders and decoders, and the plans holding them,
+// so that they are resolved again with the types registered by RegisterEnum and RegisterUnion.
+func resetCodecs() {
+	encoders.Clear()
+	decoders.Clear()
+	structPlans.Clear()
+}
+
+// indirect returns the type t points to, following any number of pointers.
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+// hasDefaults reports whether t is a struct with fields that have a default,
+// directly or in fields holding structs.
+func hasDefaults(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || t == bigIntType || t == bigFloatType {
+		return false
+	}
+
+	return planFor(t).hasDefaults
+}
+
+// typeHooks records the methods with which a type takes over its own encoding or decoding.
+type typeHooks struct {
+	marshaler          bool // the type implements Marshaler
+	ptrMarshaler       bool // a pointer to the type implements Marshaler
+	unmarshaler        bool // the type implements Unmarshaler
+	ptrUnmarshaler     bool // a pointer to the type implements Unmarshaler
+	textMarshaler      bool // the type implements encoding.TextMarshaler
+	ptrTextMarshaler   bool // a pointer to the type implements encoding.TextMarshaler
+	ptrTextUnmarshaler bool // a pointer to the type implements encoding.TextUnmarshaler
+}
+
+var hooks sync.Map // map[reflect.Type]*typeHooks
+
+// hooksFor returns the hooks of the type t, looking them up on first use.
+// It is safe for concurrent use.
+func hooksFor(t reflect.Type) *typeHooks {
+	if h, ok := hooks.Load(t); ok {
+		return h.(*typeHooks)
+	}
+
+	pt := reflect.PointerTo(t)
+
+	h := &typeHooks{
+		marshaler:          t.Implements(marshalerType),
+		ptrMarshaler:       pt.Implements(marshalerType),
+		unmarshaler:        t.Implements(unmarshalerType),
+		ptrUnmarshaler:     pt.Implements(unmarshalerType),
+		textMarshaler:      t.Implements(textMarshalerType),
+		ptrTextMarshaler:   pt.Implements(textMarshalerType),
+		ptrTextUnmarshaler: pt.Implements(textUnmarshalerType),
+	}
+
+	actual, _ := hooks.LoadOrStore(t, h)
+
+	return actual.(*typeHooks)
+}
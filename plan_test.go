@@ -0,0 +1,141 @@
+package zon
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestPlanFor(t *testing.T) {
+	type T struct {
+		Name  string         `zon:"name"`
+		Port  int            `zon:"port,default=8080"`
+		Other string         `zon:"other name"`
+		Rest  map[string]any `zon:",rest"`
+	}
+
+	typ := reflect.TypeFor[T]()
+
+	sp := planFor(typ)
+
+	if planFor(typ) != sp {
+		t.Fatal("planFor returned a different plan for the same type")
+	}
+
+	if !sp.hasDefaults {
+		t.Fatal("hasDefaults = false, want true")
+	}
+
+	if sp.rest == nil || sp.rest.goName != "Rest" {
+		t.Fatalf("rest = %+v, want field Rest", sp.rest)
+	}
+
+	if got, want := sp.fields[2].prefix, `.@"other name" = `; got != want {
+		t.Fatalf("prefix = %q, want %q", got, want)
+	}
+
+	for _, f := range sp.fields {
+		if f.encode == nil || f.decode == nil {
+			t.Fatalf("field %s has no resolved encoder and decoder", f.goName)
+		}
+	}
+
+	for _, tt := range []struct {
+		key  string
+		fold bool
+		want string
+	}{
+		{"name", false, "Name"},
+		{"NAME", false, ""},
+		{"NAME", true, "Name"},
+		{"other name", false, "Other"},
+		{"Rest", true, ""},
+		{"missing", true, ""},
+	} {
+		var got string
+
+		if f := sp.lookup(tt.key, tt.fold); f != nil {
+			got = f.goName
+		}
+
+		if got != tt.want {
+			t.Errorf("lookup(%q, %v) = %q, want %q", tt.key, tt.fold, got, tt.want)
+		}
+	}
+}
+
+func TestPlanForConcurrent(t *testing.T) {
+	type Inner struct {
+		A int    `zon:"a,default=1"`
+		B string `zon:"b"`
+	}
+
+	type Outer struct {
+		Inner Inner   `zon:"inner"`
+		List  []Inner `zon:"list"`
+	}
+
+	in := Outer{Inner: Inner{A: 2, B: "x"}, List: []Inner{{A: 3, B: "y"}}}
+
+	var wg sync.WaitGroup
+
+	for range 8 {
+		wg.Go(func() {
+			for range 50 {
+				data, err := Marshal(in)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				var out Outer
+
+				if err := Unmarshal(data, &out); err != nil {
+					t.Error(err)
+					return
+				}
+
+				if !reflect.DeepEqual(out, in) {
+					t.Errorf("got %+v, want %+v", out, in)
+					return
+				}
+			}
+		})
+	}
+
+	wg.Wait()
+}
+
+type testPlanLevel int
+
+func TestPlanForRegisterAfterUse(t *testing.T) {
+	type T struct {
+		Level testPlanLevel `zon:"level"`
+	}
+
+	data, err := Marshal(T{Level: 1}, Indent(""))
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	if got, want := string(data), ".{ .level = 1, }\n"; got != want {
+		t.Fatalf("Marshal = %q, want %q", got, want)
+	}
+
+	RegisterEnum(map[testPlanLevel]string{0: "info", 1: "warn"})
+
+	data, err = Marshal(T{Level: 1}, Indent(""))
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	if got, want := string(data), ".{ .level = .warn, }\n"; got != want {
+		t.Fatalf("Marshal = %q, want %q", got, want)
+	}
+
+	var out T
+
+	if err := Unmarshal(data, &out); err != nil || out.Level != 1 {
+		t.Fatalf("Unmarshal = %+v, %v, want level 1", out, err)
+	}
+}
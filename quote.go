@@ -0,0 +1,276 @@
+package zon
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+)
+
+const hexDigits = "0123456789abcdef"
+
+// quote returns s as a double-quoted ZON string literal.
+//
+// Bytes that are not valid UTF-8 are written as \xNN escapes,
+// so that every Go string round-trips exactly.
+func quote(s string) string {
+	b := make([]byte, 0, len(s)+2)
+
+	b = append(b, '"')
+
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+
+		if r == utf8.RuneError && size == 1 {
+			b = appendHexEscape(b, s[i])
+		} else {
+			b = appendEscapedRune(b, r, '"')
+		}
+
+		i += size
+	}
+
+	return string(append(b, '"'))
+}
+
+// quoteRune returns r as a single-quoted ZON character literal.
+func quoteRune(r rune) string {
+	b := make([]byte, 0, 8)
+
+	b = append(b, '\'')
+	b = appendEscapedRune(b, r, '\'')
+
+	return string(append(b, '\''))
+}
+
+func appendEscapedRune(b []byte, r rune, q byte) []byte {
+	switch r {
+	case '\n':
+		return append(b, `\n`...)
+	case '\r':
+		return append(b, `\r`...)
+	case '\t':
+		return append(b, `\t`...)
+	case '\\':
+		return append(b, `\\`...)
+	case rune(q):
+		return append(b, '\\', q)
+	}
+
+	if r < utf8.RuneSelf {
+		if r < 0x20 || r == 0x7f {
+			return appendHexEscape(b, byte(r))
+		}
+
+		return append(b, byte(r))
+	}
+
+	if !unicode.IsPrint(r) {
+		return append(b, fmt.Sprintf(`\u{%x}`, r)...)
+	}
+
+	return utf8.AppendRune(b, r)
+}
+
+func appendHexEscape(b []byte, c byte) []byte {
+	return append(b, '\\', 'x', hexDigits[c>>4], hexDigits[c&0xf])
+}
+
+// scanString decodes the string literal starting at data[pos],
+// returning its value and the position just after the closing quote.
+func scanString(data []byte, pos int) (string, int, error) {
+	var b []byte
+
+	i := pos + 1
+
+	for {
+		if i >= len(data) || data[i] == '\n' {
+			return "", i, syntaxError(pos, ErrInvalidString, "unterminated string")
+		}
+
+		switch c := data[i]; c {
+		case '"':
+			return string(b), i + 1, nil
+		case '\\':
+			r, isByte, end, err := scanEscape(data, i)
+			if err != nil {
+				return "", end, err
+			}
+
+			if isByte {
+				b = append(b, byte(r))
+			} else {
+				b = utf8.AppendRune(b, r)
+			}
+
+			i = end
+		default:
+			b = append(b, c)
+			i++
+		}
+	}
+}
+
+// scanChar decodes the character literal starting at data[pos],
+// returning its codepoint and the position just after the closing quote.
+func scanChar(data []byte, pos int) (rune, int, error) {
+	i := pos + 1
+
+	if i >= len(data) || data[i] == '\'' || data[i] == '\n' {
+		return 0, i, syntaxError(pos, ErrInvalidString, "invalid character literal")
+	}
+
+	var r rune
+
+	if data[i] == '\\' {
+		c, _, end, err := scanEscape(data, i)
+		if err != nil {
+			return 0, end, err
+		}
+
+		r, i = c, end
+	} else {
+		c, size := utf8.DecodeRune(data[i:])
+		if c == utf8.RuneError && size == 1 {
+			return 0, i, syntaxError(pos, ErrInvalidString, "invalid UTF-8 in character literal")
+		}
+
+		r, i = c, i+size
+	}
+
+	if i >= len(data) || data[i] != '\'' {
+		return 0, i, syntaxError(pos, ErrInvalidString, "unterminated character literal")
+	}
+
+	return r, i + 1, nil
+}
+
+// scanEscape decodes the escape sequence starting with the backslash at data[pos].
+// isByte reports whether r is a raw byte from a \xNN escape rather than a codepoint.
+func scanEscape(data []byte, pos int) (r rune, isByte bool, end int, err error) {
+	if pos+1 >= len(data) {
+		return 0, false, pos, syntaxError(pos, ErrInvalidString, "invalid escape sequence")
+	}
+
+	switch data[pos+1] {
+	case 'n':
+		return '\n', false, pos + 2, nil
+	case 'r':
+		return '\r', false, pos + 2, nil
+	case 't':
+		return '\t', false, pos + 2, nil
+	case '\\', '\'', '"':
+		return rune(data[pos+1]), false, pos + 2, nil
+	case 'x':
+		if pos+4 > len(data) || !isHexDigit(data[pos+2]) || !isHexDigit(data[pos+3]) {
+			return 0, false, pos, syntaxError(pos, ErrInvalidString, "invalid \\x escape sequence")
+		}
+
+		return rune(hexValue(data[pos+2])<<4 | hexValue(data[pos+3])), true, pos + 4, nil
+	case 'u':
+		i := pos + 2
+
+		if i >= len(data) || data[i] != '{' {
+			return 0, false, pos, syntaxError(pos, ErrInvalidString, "invalid \\u escape sequence")
+		}
+
+		i++
+
+		start := i
+
+		for i < len(data) && isHexDigit(data[i]) {
+			r = r<<4 | rune(hexValue(data[i]))
+
+			if r > unicode.MaxRune {
+				return 0, false, pos, syntaxError(pos, ErrInvalidString, "\\u escape sequence out of range")
+			}
+
+			i++
+		}
+
+		if i == start || i >= len(data) || data[i] != '}' {
+			return 0, false, pos, syntaxError(pos, ErrInvalidString, "invalid \\u escape sequence")
+		}
+
+		if r >= 0xd800 && r <= 0xdfff {
+			return 0, false, pos, syntaxError(pos, ErrInvalidString, "\\u escape sequence is a surrogate")
+		}
+
+		return r, false, i + 1, nil
+	default:
+		return 0, false, pos, syntaxError(pos, ErrInvalidString, "invalid escape sequence")
+	}
+}
+
+func hexValue(b byte) byte {
+	switch {
+	case b >= '0' && b <= '9':
+		return b - '0'
+	case b >= 'a' && b <= 'f':
+		return b - 'a' + 10
+	default:
+		return b - 'A' + 10
+	}
+}
+
+// scanMultilineString decodes the multiline string literal starting at data[pos],
+// joining consecutive \\ lines with newlines. It returns the value and the position
+// at the end of the last line.
+func scanMultilineString(data []byte, pos int) (string, int) {
+	var b []byte
+
+	i := pos
+
+	for {
+		i += 2
+
+		start := i
+
+		for i < len(data) && data[i] != '\n' {
+			i++
+		}
+
+		line := data[start:i]
+
+		if n := len(line); n > 0 && line[n-1] == '\r' {
+			line = line[:n-1]
+		}
+
+		b = append(b, line...)
+
+		j := i
+
+		for j < len(data) && (data[j] == ' ' || data[j] == '\t' || data[j] == '\r' || data[j] == '\n') {
+			j++
+		}
+
+		if !hasPrefixAt(data, j, `\\`) {
+			return string(b), i
+		}
+
+		b = append(b, '\n')
+
+		i = j
+	}
+}
+
+// canWriteMultiline reports whether s contains a newline and can be
+// written as a multiline string literal without losing information.
+func canWriteMultiline(s string) bool {
+	if !utf8.ValidString(s) {
+		return false
+	}
+
+	hasNewline := false
+
+	for _, r := range s {
+		switch {
+		case r == '\n':
+			hasNewline = true
+		case r == '\t':
+		case r < 0x20 || r == 0x7f || (r >= utf8.RuneSelf && !unicode.IsPrint(r)):
+			return false
+		}
+	}
+
+	return hasNewline
+}
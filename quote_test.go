@@ -0,0 +1,204 @@
+package zon
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestQuote(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "hello", `"hello"`},
+		{"quote", `say "hi"`, `"say \"hi\""`},
+		{"backslash", `a\b`, `"a\\b"`},
+		{"single quote", "it's", `"it's"`},
+		{"newline", "a\nb", `"a\nb"`},
+		{"carriage return and tab", "\r\t", `"\r\t"`},
+		{"control", "\x00\x1f\x7f", `"\x00\x1f\x7f"`},
+		{"invalid utf-8", "\xff\xfe", `"\xff\xfe"`},
+		{"unicode", "héllo 😀", `"héllo 😀"`},
+		{"non-printable unicode", "\u2028", `"\u{2028}"`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := quote(tt.in); got != tt.want {
+				t.Fatalf("quote(%q) = %s, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanString(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", `"hello"`, "hello"},
+		{"escapes", `"\n\r\t\\\'\""`, "\n\r\t\\'\""},
+		{"hex", `"\x41\xff"`, "A\xff"},
+		{"unicode", `"\u{41}\u{1F600}\u{10FFFF}"`, "A😀\U0010FFFF"},
+		{"trailing data", `"a" , "b"`, "a"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := scanString([]byte(tt.in), 0)
+			if err != nil {
+				t.Fatalf("scanString(%s) returned error: %v", tt.in, err)
+			}
+
+			if got != tt.want {
+				t.Fatalf("scanString(%s) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanStringErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"unterminated", `"abc`, "unterminated string at offset 0"},
+		{"newline", "\"a\nb\"", "unterminated string at offset 0"},
+		{"unknown escape", `"a\qb"`, "invalid escape sequence at offset 2"},
+		{"short hex", `"\x4"`, "invalid \\x escape sequence at offset 1"},
+		{"unicode without braces", `"\u0041"`, "invalid \\u escape sequence at offset 1"},
+		{"empty unicode", `"\u{}"`, "invalid \\u escape sequence at offset 1"},
+		{"unicode out of range", `"\u{110000}"`, "out of range at offset 1"},
+		{"surrogate", `"\u{d800}"`, "surrogate at offset 1"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := scanString([]byte(tt.in), 0)
+			if err == nil {
+				t.Fatalf("scanString(%s) returned no error", tt.in)
+			}
+
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %q, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"plain",
+		`"quoted" and \backslashed\`,
+		"multiple\nlines\r\n\ttabbed",
+		"\x00\x01\x7f\x80\xff",
+		"emoji 😀 and \u2028 separator",
+	} {
+		data, err := Marshal(s)
+		if err != nil {
+			t.Fatalf("Marshal(%q) returned error: %v", s, err)
+		}
+
+		var got string
+
+		if err := Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal(%s) returned error: %v", data, err)
+		}
+
+		if got != s {
+			t.Fatalf("round trip of %q = %q", s, got)
+		}
+	}
+}
+
+func TestScanMultilineString(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		in   string
+		want string
+		rest string
+	}{
+		{"single line", `\\hello`, "hello", ""},
+		{"joined lines", "\\\\one\n    \\\\two\n", "one\ntwo", "\n"},
+		{"crlf", "\\\\one\r\n\\\\two\r\n,", "one\ntwo", "\n,"},
+		{"escapes are literal", `\\a\nb"c`, `a\nb"c`, ""},
+		{"empty last line", "\\\\a\n\\\\\n,", "a\n", "\n,"},
+		{"stops at other token", "\\\\a\n    ,\n\\\\b", "a", "\n    ,\n\\\\b"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, end := scanMultilineString([]byte(tt.in), 0)
+
+			if got != tt.want {
+				t.Fatalf("scanMultilineString(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+
+			if rest := tt.in[end:]; rest != tt.rest {
+				t.Fatalf("rest = %q, want %q", rest, tt.rest)
+			}
+		})
+	}
+}
+
+func TestCanWriteMultiline(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want bool
+	}{
+		{"single line", false},
+		{"two\nlines", true},
+		{"tabs\tare\nfine", true},
+		{"carriage\r\nreturn", false},
+		{"invalid\n\xff", false},
+		{"control\n\x00", false},
+	} {
+		if got := canWriteMultiline(tt.in); got != tt.want {
+			t.Errorf("canWriteMultiline(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestQuoteRune(t *testing.T) {
+	for _, tt := range []struct {
+		in   rune
+		want string
+	}{
+		{'a', `'a'`},
+		{'\'', `'\''`},
+		{'"', `'"'`},
+		{'\n', `'\n'`},
+		{0, `'\x00'`},
+		{'😀', `'😀'`},
+		{0x2028, `'\u{2028}'`},
+	} {
+		if got := quoteRune(tt.in); got != tt.want {
+			t.Errorf("quoteRune(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestScanChar(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want rune
+	}{
+		{`'a'`, 'a'},
+		{`'😀'`, '😀'},
+		{`'\n'`, '\n'},
+		{`'\''`, '\''},
+		{`'\xff'`, 0xff},
+		{`'\u{1F600}'`, '😀'},
+	} {
+		got, end, err := scanChar([]byte(tt.in), 0)
+		if err != nil {
+			t.Fatalf("scanChar(%s) returned error: %v", tt.in, err)
+		}
+
+		if got != tt.want || end != len(tt.in) {
+			t.Fatalf("scanChar(%s) = %q, %d, want %q, %d", tt.in, got, end, tt.want, len(tt.in))
+		}
+	}
+
+	for _, in := range []string{`''`, `'ab'`, `'a`, `'\q'`, "'\xff'", "'\n'"} {
+		if _, _, err := scanChar([]byte(in), 0); err == nil {
+			t.Errorf("scanChar(%q) returned no error", in)
+		}
+	}
+}
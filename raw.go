@@ -0,0 +1,118 @@
+package zon
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+)
+
+// RawValue is a raw encoded ZON value.
+//
+// Decoding into a RawValue stores the source of the value as is,
+// and encoding a RawValue writes it out unchanged, or null if it is empty.
+// Encoding fails unless it holds a single value that Strict decoding accepts.
+//
+// A RawValue field tagged zon:",rest" collects the fields without a matching
+// struct field as a struct literal, and they are written back after the other fields.
+type RawValue []byte
+
+var rawValueType = reflect.TypeFor[RawValue]()
+
+// rawEntry is a field of a struct literal, with the source of its value.
+type rawEntry struct {
+	name  string
+	value []byte
+}
+
+// rawFields returns the fields of the struct literal raw.
+func rawFields(raw RawValue) ([]rawEntry, error) {
+	p := newParser(raw, UnmarshalOptions{})
+
+	open, err := p.expect(TokenLBrace)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []rawEntry
+
+	err = p.parseEntries(open, func() error {
+		key, err := p.parseKey()
+		if err != nil {
+			return err
+		}
+
+		value, err := p.rawValue()
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, rawEntry{name: key, value: value})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, p.expectEOF()
+}
+
+// validRaw returns an error unless raw holds exactly one ZON value that std.zon.parse accepts.
+func validRaw(raw []byte) error {
+	p := newParser(raw, UnmarshalOptions{Strict: true})
+
+	if err := p.parseValue(reflect.New(anyType).Elem()); err != nil {
+		return err
+	}
+
+	return p.expectEOF()
+}
+
+// marshalRaw writes the RawValue v, which must hold a single valid value, at level l.
+func marshalRaw(v reflect.Value, b *bytes.Buffer, o Options, l int) error {
+	raw := bytes.TrimSpace(v.Bytes())
+
+	if len(raw) == 0 {
+		b.WriteString("null")
+
+		return nil
+	}
+
+	if err := validRaw(raw); err != nil {
+		return fmt.Errorf("zon: invalid RawValue: %w", err)
+	}
+
+	writeRaw(b, raw, o, l)
+
+	return nil
+}
+
+// writeRaw writes the source of a value at level l. A value ending in a multiline string
+// literal is followed by a newline, since the literal runs to the end of its line.
+func writeRaw(b *bytes.Buffer, raw []byte, o Options, l int) {
+	b.Write(raw)
+
+	if endsInMultiline(raw) {
+		b.WriteByte('\n')
+
+		writeIndent(b, o, l)
+	}
+}
+
+// endsInMultiline reports whether the last token of raw is a multiline string literal.
+func endsInMultiline(raw []byte) bool {
+	s := NewScanner(raw)
+
+	last := TokenEOF
+
+	for {
+		tok, err := s.Scan()
+		if err != nil || tok.Kind == TokenEOF {
+			return last == TokenMultilineString
+		}
+
+		if tok.Kind != TokenComment {
+			last = tok.Kind
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package zon
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUnmarshalRawValue(t *testing.T) {
+	var v struct {
+		Name  string   `zon:"name"`
+		Raw   RawValue `zon:"raw"`
+		Null  RawValue `zon:"null"`
+		Multi RawValue `zon:"multi"`
+	}
+
+	data := ".{ .name = \"zon\", .raw = .{ .a = 1, // comment\n .b = .{ 2, 3 } }, .null = null, .multi = \n    \\\\one\n    \\\\two\n}"
+
+	if err := Unmarshal([]byte(data), &v); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if got, want := string(v.Raw), ".{ .a = 1, // comment\n .b = .{ 2, 3 } }"; got != want {
+		t.Errorf("Raw = %q, want %q", got, want)
+	}
+
+	if got := string(v.Null); got != "null" {
+		t.Errorf("Null = %q, want %q", got, "null")
+	}
+
+	if got, want := string(v.Multi), "\\\\one\n    \\\\two"; got != want {
+		t.Errorf("Multi = %q, want %q", got, want)
+	}
+}
+
+func TestMarshalRawValue(t *testing.T) {
+	v := struct {
+		Raw   RawValue `zon:"raw"`
+		Empty RawValue `zon:"empty"`
+	}{Raw: RawValue(" .{ 1, 2 } ")}
+
+	data, err := Marshal(v, Indent(""))
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	if got, want := string(data), ".{ .raw = .{ 1, 2 }, .empty = null, }\n"; got != want {
+		t.Fatalf("Marshal = %q, want %q", got, want)
+	}
+
+	if _, err := Marshal(RawValue(".{ 1, 2 } 3")); !errors.Is(err, ErrUnexpectedToken) {
+		t.Fatalf("Marshal of invalid RawValue returned %v, want ErrUnexpectedToken", err)
+	}
+}
+
+func TestRestField(t *testing.T) {
+	type Manifest struct {
+		Name    string         `zon:"name"`
+		Version string         `zon:"version"`
+		Rest    map[string]any `zon:",rest"`
+	}
+
+	data := `.{ .name = "zon", .version = "0.1.0", .paths = .{ "src" }, .@"weird key" = 1 }`
+
+	var m Manifest
+
+	if err := Unmarshal([]byte(data), &m, DisallowUnknownFields(true)); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	want := map[string]any{"paths": []any{"src"}, "weird key": int64(1)}
+
+	if !reflect.DeepEqual(m.Rest, want) {
+		t.Fatalf("Rest = %#v, want %#v", m.Rest, want)
+	}
+
+	m.Version = "0.2.0"
+
+	out, err := Marshal(m, Indent(""))
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	if got, want := string(out), `.{ .name = "zon", .version = "0.2.0", .paths = .{ "src", }, .@"weird key" = 1, }`+"\n"; got != want {
+		t.Fatalf("Marshal = %q, want %q", got, want)
+	}
+
+	m.Rest["name"] = "duplicate"
+
+	if _, err := Marshal(m); err == nil {
+		t.Fatal("Marshal with a rest entry named like a field returned no error")
+	}
+}
+
+func TestRestFieldRawValueRoundTrip(t *testing.T) {
+	type Manifest struct {
+		Name    any      `zon:"name"`
+		Version string   `zon:"version"`
+		Rest    RawValue `zon:",rest"`
+	}
+
+	src, err := os.ReadFile("testdata/build.zig.zon")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var m Manifest
+
+	if err := Unmarshal(src, &m); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if !strings.HasPrefix(string(m.Rest), ".{ .fingerprint = 0x99e5365e8f803dab, .minimum_zig_version = ") {
+		t.Fatalf("Rest = %q", m.Rest)
+	}
+
+	m.Version = "1.0.0"
+
+	out, err := Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	var before, after map[string]any
+
+	if err := Unmarshal(src, &before); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if err := Unmarshal(out, &after); err != nil {
+		t.Fatalf("Unmarshal of %s returned error: %v", out, err)
+	}
+
+	before["version"] = "1.0.0"
+
+	if !reflect.DeepEqual(before, after) {
+		t.Fatalf("round trip lost data\nbefore: %#v\nafter:  %#v", before, after)
+	}
+}
+
+func TestRawValueMultilineString(t *testing.T) {
+	type R struct {
+		V RawValue `zon:"V"`
+		W int      `zon:"W"`
+	}
+
+	for _, indent := range []string{"", "    "} {
+		data, err := Marshal(R{V: RawValue(`\\line`), W: 1}, Indent(indent))
+		if err != nil {
+			t.Fatalf("Marshal returned error: %v", err)
+		}
+
+		var out R
+
+		if err := Unmarshal(data, &out, Strict(true)); err != nil {
+			t.Fatalf("Unmarshal(%q) returned error: %v", data, err)
+		}
+
+		if string(out.V) != `\\line` || out.W != 1 {
+			t.Fatalf("Unmarshal(%q) = %+v", data, out)
+		}
+	}
+}
+
+func TestRestFieldMultilineString(t *testing.T) {
+	type T struct {
+		Name string   `zon:"name"`
+		Rest RawValue `zon:",rest"`
+	}
+
+	in := ".{ .name = \"x\", .desc =\n    \\\\hello\n    \\\\world\n, .n = 1 }"
+
+	var v T
+
+	if err := Unmarshal([]byte(in), &v); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if _, err := rawFields(v.Rest); err != nil {
+		t.Fatalf("rest %q is not a valid struct literal: %v", v.Rest, err)
+	}
+
+	data, err := Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	var got map[string]any
+
+	if err := Unmarshal(data, &got, Strict(true)); err != nil {
+		t.Fatalf("Unmarshal(%q) returned error: %v", data, err)
+	}
+
+	want := map[string]any{"name": "x", "desc": "hello\nworld", "n": int64(1)}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("round trip = %#v, want %#v", got, want)
+	}
+}
+
+func TestMarshalInvalidRawValue(t *testing.T) {
+	for _, raw := range []string{"foo", ".{ 1 2 }", ".{ .a = 1, .a = 2 }", "1 2"} {
+		if data, err := Marshal(struct{ V RawValue }{RawValue(raw)}); err == nil {
+			t.Errorf("Marshal of RawValue %q = %q, want error", raw, data)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package zon
+
+import (
+	"maps"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// registry maps types to what was registered for them. Lookups read an immutable map
+// without locking, and registering a type replaces the whole map.
+type registry[V any] struct {
+	mu sync.Mutex
+	m  atomic.Pointer[map[reflect.Type]V]
+}
+
+// load returns the value registered for t, and whether there is one.
+func (r *registry[V]) load(t reflect.Type) (V, bool) {
+	if m := r.m.Load(); m != nil {
+		v, ok := (*m)[t]
+
+		return v, ok
+	}
+
+	var zero V
+
+	return zero, false
+}
+
+// store registers v for t, replacing any earlier value.
+// The resolved encoders and decoders are dropped, as they may depend on it.
+func (r *registry[V]) store(t reflect.Type, v V) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m := map[reflect.Type]V{}
+
+	if old := r.m.Load(); old != nil {
+		maps.Copy(m, *old)
+	}
+
+	m[t] = v
+
+	r.m.Store(&m)
+
+	resetCodecs()
+}
@@ -2,6 +2,7 @@ package zon
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -137,3 +138,156 @@ func mapsDeepEqual(a, b map[string]any) bool {
 
 	return true
 }
+
+func TestTagOptionsRoundTrip(t *testing.T) {
+	type T struct {
+		Name  string `zon:"name"`
+		Omit  []int  `zon:"omit,omitempty"`
+		Count int    `zon:"count,string"`
+	}
+
+	in := T{Name: "zon", Omit: []int{1}, Count: 3}
+
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	var out T
+
+	if err := Unmarshal(data, &out, Strict(true), DisallowUnknownFields(true)); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(out, in) {
+		t.Fatalf("out = %+v, want %+v", out, in)
+	}
+}
+
+func TestEmbeddedStructs(t *testing.T) {
+	type Common struct {
+		Name    string `zon:"name"`
+		Version string `zon:"version"`
+	}
+
+	type Paths struct {
+		Paths []string `zon:"paths"`
+	}
+
+	type Package struct {
+		Common
+		*Paths
+		Version string `zon:"version"`
+	}
+
+	in := Package{
+		Common:  Common{Name: "zon", Version: "shadowed"},
+		Paths:   &Paths{Paths: []string{"src"}},
+		Version: "0.1.0",
+	}
+
+	data, err := Marshal(in, Indent(""))
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	want := `.{ .name = "zon", .paths = .{ "src", }, .version = "0.1.0", }` + "\n"
+
+	if got := string(data); got != want {
+		t.Fatalf("Marshal = %q, want %q", got, want)
+	}
+
+	var out Package
+
+	if err := Unmarshal(data, &out, Strict(true), DisallowUnknownFields(true)); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if out.Name != "zon" || out.Version != "0.1.0" || out.Common.Version != "" || out.Paths == nil || !reflect.DeepEqual(out.Paths.Paths, []string{"src"}) {
+		t.Fatalf("out = %+v", out)
+	}
+
+	data, err = Marshal(Package{Common: Common{Name: "nil paths"}}, Indent(""))
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	if want := `.{ .name = "nil paths", .version = "", }` + "\n"; string(data) != want {
+		t.Fatalf("Marshal = %q, want %q", data, want)
+	}
+}
+
+func TestEmbeddedUnexportedPointer(t *testing.T) {
+	type inner struct {
+		A int `zon:"a"`
+	}
+
+	type T struct {
+		*inner
+	}
+
+	var v T
+
+	if err := Unmarshal([]byte(`.{ .a = 1 }`), &v); err == nil {
+		t.Fatal("Unmarshal into nil embedded pointer to unexported struct returned no error")
+	}
+}
+
+func TestMapKeysRoundTrip(t *testing.T) {
+	type Name string
+
+	type T struct {
+		Ints  map[int]string  `zon:"ints"`
+		Uints map[uint16]bool `zon:"uints"`
+		Names map[Name]int    `zon:"names"`
+	}
+
+	in := T{
+		Ints:  map[int]string{-1: "a", 0: "b", 12: "c"},
+		Uints: map[uint16]bool{7: true},
+		Names: map[Name]int{"x": 1, "y z": 2},
+	}
+
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	var out T
+
+	if err := Unmarshal(data, &out, Strict(true)); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(out, in) {
+		t.Fatalf("out = %+v, want %+v", out, in)
+	}
+
+	var bad map[uint8]int
+
+	err = Unmarshal([]byte(`.{ .@"300" = 1 }`), &bad)
+
+	var te *UnmarshalTypeError
+	if !errors.As(err, &te) {
+		t.Fatalf("Unmarshal of out of range key returned %v, want *UnmarshalTypeError", err)
+	}
+}
+
+func TestMapKeysWithDotRoundTrip(t *testing.T) {
+	in := map[string]int{".env": 1, "env": 2}
+
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	var out map[string]int
+
+	if err := Unmarshal(data, &out, Strict(true)); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(out, in) {
+		t.Fatalf("out = %v, want %v", out, in)
+	}
+}
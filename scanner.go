@@ -0,0 +1,295 @@
+package zon
+
+import "fmt"
+
+// TokenKind is the kind of a ZON token.
+type TokenKind int
+
+const (
+	TokenEOF             TokenKind = iota // end of input
+	TokenLBrace                           // .{
+	TokenRBrace                           // }
+	TokenEqual                            // =
+	TokenComma                            // ,
+	TokenMinus                            // - not directly followed by a digit, as in -inf
+	TokenIdent                            // bare identifier such as inf or nan, or @"..."
+	TokenEnumLiteral                      // .name or .@"name"
+	TokenString                           // "..."
+	TokenMultilineString                  // one or more consecutive \\ lines
+	TokenNumber                           // integer or float literal, including a leading -
+	TokenChar                             // 'c'
+	TokenTrue                             // true
+	TokenFalse                            // false
+	TokenNull                             // null
+	TokenComment                          // // to the end of the line
+)
+
+var tokenKindNames = [...]string{
+	TokenEOF:             "end of input",
+	TokenLBrace:          "'.{'",
+	TokenRBrace:          "'}'",
+	TokenEqual:           "'='",
+	TokenComma:           "','",
+	TokenMinus:           "'-'",
+	TokenIdent:           "identifier",
+	TokenEnumLiteral:     "enum literal",
+	TokenString:          "string",
+	TokenMultilineString: "multiline string",
+	TokenNumber:          "number",
+	TokenChar:            "character literal",
+	TokenTrue:            "true",
+	TokenFalse:           "false",
+	TokenNull:            "null",
+	TokenComment:         "comment",
+}
+
+// fixedText holds the source text of the tokens that are always spelled the same,
+// so that scanning them does not allocate.
+var fixedText = [...]string{
+	TokenLBrace: ".{",
+	TokenTrue:   "true",
+	TokenFalse:  "false",
+	TokenNull:   "null",
+}
+
+func (k TokenKind) String() string {
+	if k >= 0 && int(k) < len(tokenKindNames) {
+		return tokenKindNames[k]
+	}
+
+	return fmt.Sprintf("TokenKind(%d)", int(k))
+}
+
+// Token is a single lexical token of a ZON document.
+type Token struct {
+	Kind   TokenKind
+	Text   string // the source text of the token
+	Offset int    // byte offset of the first byte, starting at 0
+	Line   int    // line number, starting at 1
+	Column int    // byte column within the line, starting at 1
+}
+
+// End returns the byte offset just after the token.
+func (t Token) End() int {
+	return t.Offset + len(t.Text)
+}
+
+// Scanner splits ZON input into tokens.
+//
+// Whitespace between tokens is skipped, while comments are returned as
+// TokenComment tokens. Once Scan has returned an error, every following
+// call returns the same error.
+type Scanner struct {
+	data      []byte
+	pos       int
+	line      int
+	lineStart int
+	err       error
+}
+
+// NewScanner returns a Scanner reading tokens from data.
+func NewScanner(data []byte) *Scanner {
+	return &Scanner{data: data, line: 1}
+}
+
+// Scan returns the next token, or a token of kind TokenEOF at the end of the input.
+func (s *Scanner) Scan() (Token, error) {
+	if s.err != nil {
+		return Token{}, s.err
+	}
+
+	s.skipWhitespace()
+
+	start := s.pos
+
+	kind, end, err := s.scan()
+	if err != nil {
+		if se, ok := err.(*SyntaxError); ok {
+			err = se.locate(s.data)
+		}
+
+		s.err = err
+
+		return Token{}, err
+	}
+
+	tok := Token{
+		Kind:   kind,
+		Offset: start,
+		Line:   s.line,
+		Column: start - s.lineStart + 1,
+	}
+
+	if int(kind) < len(fixedText) && fixedText[kind] != "" {
+		tok.Text = fixedText[kind]
+	} else {
+		tok.Text = string(s.data[start:end])
+	}
+
+	s.advance(end)
+
+	return tok, nil
+}
+
+func (s *Scanner) scan() (TokenKind, int, error) {
+	data, pos := s.data, s.pos
+
+	if pos >= len(data) {
+		return TokenEOF, pos, nil
+	}
+
+	switch c := data[pos]; {
+	case hasPrefixAt(data, pos, "//"):
+		end := pos
+
+		for end < len(data) && data[end] != '\n' {
+			end++
+		}
+
+		return TokenComment, end, nil
+	case hasPrefixAt(data, pos, ".{"):
+		return TokenLBrace, pos + 2, nil
+	case c == '.':
+		_, end, err := scanName(data, pos+1)
+
+		return TokenEnumLiteral, end, err
+	case c == '}':
+		return TokenRBrace, pos + 1, nil
+	case c == '=':
+		return TokenEqual, pos + 1, nil
+	case c == ',':
+		return TokenComma, pos + 1, nil
+	case c == '"':
+		_, end, err := scanString(data, pos)
+
+		return TokenString, end, err
+	case hasPrefixAt(data, pos, `\\`):
+		_, end := scanMultilineString(data, pos)
+
+		return TokenMultilineString, end, nil
+	case c == '\'':
+		_, end, err := scanChar(data, pos)
+
+		return TokenChar, end, err
+	case isDigit(c) || c == '-' && pos+1 < len(data) && isDigit(data[pos+1]):
+		end := scanNumber(data, pos)
+
+		return TokenNumber, end, validateNumber(string(data[pos:end]), pos)
+	case c == '-':
+		return TokenMinus, pos + 1, nil
+	case c == '@' || isLetter(c) || c == '_':
+		_, end, err := scanName(data, pos)
+		if err != nil {
+			return TokenIdent, end, err
+		}
+
+		switch string(data[pos:end]) {
+		case "true":
+			return TokenTrue, end, nil
+		case "false":
+			return TokenFalse, end, nil
+		case "null":
+			return TokenNull, end, nil
+		}
+
+		return TokenIdent, end, nil
+	default:
+		return TokenEOF, pos, syntaxError(pos, ErrInvalidCharacter, "unexpected character %q", c)
+	}
+}
+
+func (s *Scanner) skipWhitespace() {
+	for s.pos < len(s.data) {
+		switch s.data[s.pos] {
+		case ' ', '\t', '\r':
+			s.pos++
+		case '\n':
+			s.pos++
+			s.line++
+			s.lineStart = s.pos
+		default:
+			return
+		}
+	}
+}
+
+// advance moves the scanner to end, keeping track of lines spanned by the token.
+func (s *Scanner) advance(end int) {
+	for ; s.pos < end; s.pos++ {
+		if s.data[s.pos] == '\n' {
+			s.line++
+			s.lineStart = s.pos + 1
+		}
+	}
+}
+
+// Unexpected returns a SyntaxError reporting that tok was found where expected was wanted.
+func (s *Scanner) Unexpected(tok Token, expected string) *SyntaxError {
+	err := ErrUnexpectedToken
+
+	if tok.Kind == TokenEOF {
+		err = ErrUnexpectedEOF
+	}
+
+	e := &SyntaxError{Offset: tok.Offset, Expected: expected, Found: describe(tok), Err: err}
+
+	return e.locate(s.data)
+}
+
+// validateNumber checks that lit is a valid integer or float literal.
+func validateNumber(lit string, pos int) error {
+	var err error
+
+	if isFloatLiteral(lit) {
+		_, err = normalizeFloatLiteral(lit)
+	} else {
+		_, _, _, err = parseIntLiteral(lit)
+	}
+
+	if err != nil {
+		return syntaxError(pos, ErrInvalidNumber, "%v", err)
+	}
+
+	return nil
+}
+
+// Quote returns s as a double-quoted ZON string literal.
+func Quote(s string) string {
+	return quote(s)
+}
+
+// Unquote returns the value of a string, multiline string or character literal.
+// The value of a character literal is the UTF-8 encoding of its codepoint.
+func Unquote(lit string) (string, error) {
+	data := []byte(lit)
+
+	var (
+		s   string
+		end int
+		err error
+	)
+
+	switch {
+	case hasPrefixAt(data, 0, `\\`):
+		s, end = scanMultilineString(data, 0)
+	case hasPrefixAt(data, 0, `"`):
+		s, end, err = scanString(data, 0)
+	case hasPrefixAt(data, 0, `'`):
+		var r rune
+
+		r, end, err = scanChar(data, 0)
+		s = string(r)
+	default:
+		return "", syntaxError(0, ErrInvalidString, "invalid quoted literal %q", lit)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if end != len(data) {
+		return "", syntaxError(end, ErrInvalidString, "invalid quoted literal %q", lit)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,169 @@
+package zon
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestScanner(t *testing.T) {
+	data := ".{\n" +
+		"    .name = .@\"foo-bar\", // trailing\n" +
+		"    .n = -0x1F, .f = -inf, .c = 'x',\n" +
+		"    .s = \\\\one\n" +
+		"         \\\\two\n" +
+		"    , .b = .{ true, false, null, nan },\n" +
+		"}"
+
+	want := []Token{
+		{TokenLBrace, ".{", 0, 1, 1},
+		{TokenEnumLiteral, ".name", 7, 2, 5},
+		{TokenEqual, "=", 13, 2, 11},
+		{TokenEnumLiteral, `.@"foo-bar"`, 15, 2, 13},
+		{TokenComma, ",", 26, 2, 24},
+		{TokenComment, "// trailing", 28, 2, 26},
+		{TokenEnumLiteral, ".n", 44, 3, 5},
+		{TokenEqual, "=", 47, 3, 8},
+		{TokenNumber, "-0x1F", 49, 3, 10},
+		{TokenComma, ",", 54, 3, 15},
+		{TokenEnumLiteral, ".f", 56, 3, 17},
+		{TokenEqual, "=", 59, 3, 20},
+		{TokenMinus, "-", 61, 3, 22},
+		{TokenIdent, "inf", 62, 3, 23},
+		{TokenComma, ",", 65, 3, 26},
+		{TokenEnumLiteral, ".c", 67, 3, 28},
+		{TokenEqual, "=", 70, 3, 31},
+		{TokenChar, "'x'", 72, 3, 33},
+		{TokenComma, ",", 75, 3, 36},
+		{TokenEnumLiteral, ".s", 81, 4, 5},
+		{TokenEqual, "=", 84, 4, 8},
+		{TokenMultilineString, "\\\\one\n         \\\\two", 86, 4, 10},
+		{TokenComma, ",", 111, 6, 5},
+		{TokenEnumLiteral, ".b", 113, 6, 7},
+		{TokenEqual, "=", 116, 6, 10},
+		{TokenLBrace, ".{", 118, 6, 12},
+		{TokenTrue, "true", 121, 6, 15},
+		{TokenComma, ",", 125, 6, 19},
+		{TokenFalse, "false", 127, 6, 21},
+		{TokenComma, ",", 132, 6, 26},
+		{TokenNull, "null", 134, 6, 28},
+		{TokenComma, ",", 138, 6, 32},
+		{TokenIdent, "nan", 140, 6, 34},
+		{TokenRBrace, "}", 144, 6, 38},
+		{TokenComma, ",", 145, 6, 39},
+		{TokenRBrace, "}", 147, 7, 1},
+		{TokenEOF, "", 148, 7, 2},
+	}
+
+	s := NewScanner([]byte(data))
+
+	for i, w := range want {
+		got, err := s.Scan()
+		if err != nil {
+			t.Fatalf("token %d: Scan returned error: %v", i, err)
+		}
+
+		if got != w {
+			t.Fatalf("token %d = %+v, want %+v", i, got, w)
+		}
+	}
+}
+
+func TestScannerErrors(t *testing.T) {
+	for _, tt := range []struct {
+		data string
+		want string
+	}{
+		{`.{ .a = "unterminated }`, "unterminated string at line 1, column 9"},
+		{`.{ .a = 1__0 }`, "invalid underscore"},
+		{`.{ .a = 0x }`, "invalid integer literal"},
+		{`.{ .a = 1.e5 }`, "invalid float literal"},
+		{`.{ .a = # }`, "unexpected character '#' at line 1, column 9"},
+		{`.{ . = 1 }`, "expected identifier at line 1, column 5"},
+		{`.{ .a = '\q' }`, "invalid escape sequence at line 1, column 10"},
+	} {
+		s := NewScanner([]byte(tt.data))
+
+		var err error
+
+		for err == nil {
+			var tok Token
+
+			if tok, err = s.Scan(); tok.Kind == TokenEOF && err == nil {
+				break
+			}
+		}
+
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("scanning %q: err = %v, want it to contain %q", tt.data, err, tt.want)
+		}
+
+		if _, again := s.Scan(); again != err {
+			t.Errorf("scanning %q: error is not sticky, got %v", tt.data, again)
+		}
+	}
+}
+
+func TestScannerTestdata(t *testing.T) {
+	for _, name := range []string{"testdata/build.zig.zon", "testdata/comments.zon", "testdata/example.zon"} {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := NewScanner(data)
+
+		var text strings.Builder
+
+		for {
+			tok, err := s.Scan()
+			if err != nil {
+				t.Fatalf("%s: Scan returned error: %v", name, err)
+			}
+
+			if tok.Kind == TokenEOF {
+				break
+			}
+
+			if got := string(data[tok.Offset:tok.End()]); got != tok.Text {
+				t.Fatalf("%s: token text %q does not match source %q", name, tok.Text, got)
+			}
+
+			text.WriteString(tok.Text)
+		}
+
+		if want := strings.Join(strings.Fields(string(data)), ""); strings.Join(strings.Fields(text.String()), "") != want {
+			t.Fatalf("%s: tokens do not cover the input", name)
+		}
+	}
+}
+
+func TestUnquote(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want string
+	}{
+		{`"a\tb"`, "a\tb"},
+		{"\\\\one\n    \\\\two", "one\ntwo"},
+		{`'\u{1F600}'`, "😀"},
+	} {
+		got, err := Unquote(tt.in)
+		if err != nil {
+			t.Fatalf("Unquote(%q) returned error: %v", tt.in, err)
+		}
+
+		if got != tt.want {
+			t.Errorf("Unquote(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{``, `abc`, `"a" + "b"`, `'ab'`} {
+		if _, err := Unquote(in); err == nil {
+			t.Errorf("Unquote(%q) returned no error", in)
+		}
+	}
+
+	if got := Quote("a\"b"); got != `"a\"b"` {
+		t.Errorf("Quote = %s", got)
+	}
+}
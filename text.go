@@ -0,0 +1,87 @@
+package zon
+
+import (
+	"bytes"
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+var (
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// textMarshaler returns the encoding.TextMarshaler implemented by v or by a pointer to v, if any.
+// Pointers are left to be dereferenced first, so that nil pointers encode as null.
+func textMarshaler(v reflect.Value) (encoding.TextMarshaler, bool) {
+	if !v.CanInterface() || v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer {
+		return nil, false
+	}
+
+	h := hooksFor(v.Type())
+
+	if h.textMarshaler {
+		return v.Interface().(encoding.TextMarshaler), true
+	}
+
+	if !h.ptrTextMarshaler {
+		return nil, false
+	}
+
+	if !v.CanAddr() {
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		v = c
+	}
+
+	return v.Addr().Interface().(encoding.TextMarshaler), true
+}
+
+// textUnmarshaler returns the encoding.TextUnmarshaler implemented by a pointer to v, if any.
+func textUnmarshaler(v reflect.Value) (encoding.TextUnmarshaler, bool) {
+	if !v.CanAddr() || !v.CanInterface() || v.Kind() == reflect.Interface {
+		return nil, false
+	}
+
+	if !hooksFor(v.Type()).ptrTextUnmarshaler {
+		return nil, false
+	}
+
+	return v.Addr().Interface().(encoding.TextUnmarshaler), true
+}
+
+// marshalText writes the text of m as a string literal.
+func marshalText(m encoding.TextMarshaler, t reflect.Type, b *bytes.Buffer) error {
+	text, err := m.MarshalText()
+	if err != nil {
+		return fmt.Errorf("zon: error calling MarshalText for type %s: %w", t, err)
+	}
+
+	b.WriteString(quote(string(text)))
+
+	return nil
+}
+
+// parseText parses a string literal and passes its contents to u.
+func (p *parser) parseText(v reflect.Value, u encoding.TextUnmarshaler) error {
+	tok, err := p.next()
+	if err != nil {
+		return err
+	}
+
+	if tok.Kind != TokenString && tok.Kind != TokenMultilineString {
+		return p.mismatch(tok, v.Type(), "string")
+	}
+
+	s, err := Unquote(tok.Text)
+	if err != nil {
+		return err
+	}
+
+	if err := u.UnmarshalText([]byte(s)); err != nil {
+		return p.methodError(tok, "UnmarshalText", v.Type(), err)
+	}
+
+	return nil
+}
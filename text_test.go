@@ -0,0 +1,114 @@
+package zon
+
+import (
+	"errors"
+	"math/big"
+	"net/netip"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type testID struct{ n int }
+
+var errNoPrefix = errors.New("id without prefix")
+
+func (id *testID) MarshalText() ([]byte, error) {
+	return []byte("id-" + strconv.Itoa(id.n)), nil
+}
+
+func (id *testID) UnmarshalText(text []byte) error {
+	s, ok := strings.CutPrefix(string(text), "id-")
+	if !ok {
+		return errNoPrefix
+	}
+
+	return Unmarshal([]byte(s), &id.n)
+}
+
+func TestTextMarshaler(t *testing.T) {
+	type Config struct {
+		Port int `zon:"port"`
+	}
+
+	type T struct {
+		Addr    netip.Addr            `zon:"addr"`
+		ID      testID                `zon:"id"`
+		Ptr     *testID               `zon:"ptr"`
+		Nil     *netip.Addr           `zon:"nil"`
+		Float   *big.Float            `zon:"float"`
+		Servers map[netip.Addr]Config `zon:"servers"`
+		Names   map[netip.Prefix]bool `zon:"names"`
+	}
+
+	in := T{
+		Addr:  netip.MustParseAddr("10.0.0.1"),
+		ID:    testID{7},
+		Ptr:   &testID{8},
+		Float: big.NewFloat(1.5),
+		Servers: map[netip.Addr]Config{
+			netip.MustParseAddr("::1"):       {Port: 80},
+			netip.MustParseAddr("127.0.0.1"): {Port: 8080},
+		},
+		Names: map[netip.Prefix]bool{netip.MustParsePrefix("10.0.0.0/8"): true},
+	}
+
+	data, err := Marshal(in, Indent(""))
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	want := `.{ .addr = "10.0.0.1", .id = "id-7", .ptr = "id-8", .nil = null, .float = 1.5, ` +
+		`.servers = .{ .@"127.0.0.1" = .{ .port = 8080, }, .@"::1" = .{ .port = 80, }, }, ` +
+		`.names = .{ .@"10.0.0.0/8" = true, }, }` + "\n"
+
+	if got := string(data); got != want {
+		t.Fatalf("Marshal =\n%s\nwant\n%s", got, want)
+	}
+
+	var out T
+
+	if err := Unmarshal(data, &out, Strict(true)); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if out.Float.Cmp(in.Float) != 0 {
+		t.Fatalf("Float = %v, want %v", out.Float, in.Float)
+	}
+
+	out.Float = in.Float
+
+	if !reflect.DeepEqual(out, in) {
+		t.Fatalf("out = %+v, want %+v", out, in)
+	}
+}
+
+func TestTextUnmarshalerErrors(t *testing.T) {
+	var v struct {
+		Addr    netip.Addr         `zon:"addr"`
+		ID      testID             `zon:"id"`
+		Servers map[netip.Addr]int `zon:"servers"`
+	}
+
+	for _, tt := range []struct {
+		in   string
+		want string
+	}{
+		{`.{ .addr = 1 }`, "zon: cannot unmarshal number 1 into Go struct field Addr of type netip.Addr at .addr, line 1, column 12"},
+		{`.{ .addr = "nope" }`, `zon: error calling UnmarshalText for type netip.Addr at .addr, line 1, column 12: ParseAddr("nope"): unable to parse IP`},
+		{`.{ .id = "7" }`, "zon: error calling UnmarshalText for type zon.testID at .id, line 1, column 10: id without prefix"},
+		{".{ .servers = .{\n  .host = 1 } }", `zon: error calling UnmarshalText for type netip.Addr at .servers.host, line 2, column 3: ParseAddr("host"): unable to parse IP`},
+	} {
+		err := Unmarshal([]byte(tt.in), &v)
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("Unmarshal(%s) error = %v, want %s", tt.in, err, tt.want)
+		}
+	}
+
+	var id testID
+
+	if err := Unmarshal([]byte(`"7"`), &id); !errors.Is(err, errNoPrefix) {
+		t.Fatalf("Unmarshal error = %v, want it to wrap %v", err, errNoPrefix)
+	}
+}
@@ -0,0 +1,140 @@
+package zon
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// unionType holds the variants of an interface type registered with RegisterUnion.
+type unionType struct {
+	types map[string]reflect.Type // variant type by tag
+	tags  map[reflect.Type]string // tag by variant type
+	valid string                  // sorted list of the tags as enum literals, for errors
+}
+
+var unions registry[*unionType]
+
+// RegisterUnion makes the interface type T decode and encode like a Zig tagged union,
+// where a value is a struct literal with a single field named after its variant:
+//
+//	.{ .tcp = .{ .port = 80 } }
+//
+// The variants map each tag to a value of the Go type used for that variant, like
+//
+//	type Listener interface{ isListener() }
+//
+//	zon.RegisterUnion(map[string]Listener{"tcp": TCP{}, "unix": &Unix{}, "none": None{}})
+//
+// Variants of a struct type without fields, or a pointer to one, are void and written
+// as an enum literal, such as .none.
+//
+// Decoding an unknown tag is an error, as is encoding a value of a type that is not a variant.
+// Registering a type again replaces its variants.
+// RegisterUnion panics if T is not an interface type, a variant is nil,
+// or two tags have the same type.
+func RegisterUnion[T any](variants map[string]T) {
+	t := reflect.TypeFor[T]()
+
+	if t.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("zon: RegisterUnion: %s is not an interface type", t))
+	}
+
+	u := &unionType{
+		types: make(map[string]reflect.Type, len(variants)),
+		tags:  make(map[reflect.Type]string, len(variants)),
+	}
+
+	literals := make([]string, 0, len(variants))
+
+	for tag, v := range variants {
+		vt := reflect.TypeOf(v)
+
+		if vt == nil {
+			panic(fmt.Sprintf("zon: RegisterUnion: nil variant %q", tag))
+		}
+
+		if other, ok := u.tags[vt]; ok {
+			panic(fmt.Sprintf("zon: RegisterUnion: variants %q and %q have the same type %s", other, tag, vt))
+		}
+
+		u.types[tag] = vt
+		u.tags[vt] = tag
+
+		literals = append(literals, EnumLiteral(tag).String())
+	}
+
+	slices.Sort(literals)
+
+	u.valid = strings.Join(literals, ", ")
+
+	unions.store(t, u)
+}
+
+// lookupUnion returns the registered union type t, or nil if t is not registered.
+func lookupUnion(t reflect.Type) *unionType {
+	u, _ := unions.load(t)
+
+	return u
+}
+
+// tag returns the tag of the variant held by the interface value v.
+func (u *unionType) tag(v reflect.Value) (string, error) {
+	tag, ok := u.tags[v.Elem().Type()]
+	if !ok {
+		return "", fmt.Errorf("zon: %s is not a variant of union %s, expected one of %s", v.Elem().Type(), v.Type(), u.valid)
+	}
+
+	return tag, nil
+}
+
+// isVoid reports whether the variant type t has no payload.
+func isVoid(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Struct && t.NumField() == 0
+}
+
+// marshalUnion writes the interface value v, whose type is the registered union u,
+// as a struct literal with a single field named after its variant, or as an enum literal
+// if the variant is void.
+func marshalUnion(v reflect.Value, u *unionType, b *bytes.Buffer, o Options, l int) error {
+	tag, err := u.tag(v)
+	if err != nil {
+		return err
+	}
+
+	if isVoid(v.Elem().Type()) {
+		b.WriteString(EnumLiteral(tag).String())
+
+		return nil
+	}
+
+	n := "\n"
+
+	if o.Indent == "" {
+		n = " "
+	}
+
+	b.WriteString(".{" + n)
+
+	writeIndent(b, o, l+1)
+
+	b.WriteString(EnumLiteral(tag).String() + " = ")
+
+	if err := marshal(v.Elem(), b, o, l+1); err != nil {
+		return err
+	}
+
+	b.WriteString("," + n)
+
+	writeIndent(b, o, l)
+
+	b.WriteByte('}')
+
+	return nil
+}
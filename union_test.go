@@ -0,0 +1,137 @@
+package zon
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type testListener interface{ isTestListener() }
+
+type testTCP struct {
+	Host string `zon:"host"`
+	Port int    `zon:"port"`
+}
+
+type testUnix struct {
+	Path string `zon:"path"`
+}
+
+type testNone struct{}
+
+func (testTCP) isTestListener()   {}
+func (*testUnix) isTestListener() {}
+func (testNone) isTestListener()  {}
+
+func init() {
+	RegisterUnion(map[string]testListener{
+		"tcp":  testTCP{},
+		"unix": &testUnix{},
+		"none": testNone{},
+	})
+}
+
+func TestRegisterUnion(t *testing.T) {
+	type T struct {
+		Listen    testListener   `zon:"listen"`
+		Fallbacks []testListener `zon:"fallbacks"`
+		Nothing   testListener   `zon:"nothing"`
+	}
+
+	in := T{
+		Listen:    testTCP{Host: "localhost", Port: 80},
+		Fallbacks: []testListener{&testUnix{Path: "/tmp/s"}, testNone{}},
+	}
+
+	data, err := Marshal(in, Indent(""))
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	want := `.{ .listen = .{ .tcp = .{ .host = "localhost", .port = 80, }, }, .fallbacks = .{ .{ .unix = .{ .path = "/tmp/s", }, }, .none, }, .nothing = null, }` + "\n"
+
+	if got := string(data); got != want {
+		t.Fatalf("Marshal = %s, want %s", got, want)
+	}
+
+	var out T
+
+	if err := Unmarshal(data, &out, Strict(true)); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(out, in) {
+		t.Fatalf("out = %#v, want %#v", out, in)
+	}
+
+	data, err = Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	indented := `.{
+    .listen = .{
+        .tcp = .{
+            .host = "localhost",
+            .port = 80,
+        },
+    },
+`
+
+	if got := string(data); got[:len(indented)] != indented {
+		t.Fatalf("Marshal =\n%s\nwant prefix\n%s", got, indented)
+	}
+}
+
+type testOther struct{}
+
+func (testOther) isTestListener() {}
+
+func TestRegisterUnionErrors(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want string
+	}{
+		{
+			`.{ .udp = .{} }`,
+			"zon: unknown variant .udp for union zon.testListener, expected one of .none, .tcp, .unix at line 1, column 4",
+		},
+		{
+			`.{ .tcp = .{}, .none = .{} }`,
+			"zon: union zon.testListener with more than one field at line 1, column 16",
+		},
+		{
+			`.{}`,
+			"zon: union zon.testListener without a field at line 1, column 1",
+		},
+		{
+			`.tcp`,
+			"zon: cannot unmarshal enum literal into Go value of type zon.testTCP at line 1, column 1",
+		},
+		{
+			`.{ .tcp = .{ .port = "80" } }`,
+			`zon: cannot unmarshal string into Go struct field testTCP.Port of type int at .tcp.port, line 1, column 22`,
+		},
+		{
+			`1`,
+			"zon: cannot unmarshal number 1 into Go value of type zon.testListener at line 1, column 1",
+		},
+	} {
+		var v testListener
+
+		err := Unmarshal([]byte(tt.in), &v)
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("Unmarshal(%s) error = %v, want %s", tt.in, err, tt.want)
+		}
+	}
+
+	var v testListener
+
+	if err := Unmarshal([]byte(`.udp`), &v); !errors.Is(err, ErrUnknownVariant) {
+		t.Fatalf("Unmarshal error = %v, want ErrUnknownVariant", err)
+	}
+
+	if _, err := Marshal(struct{ L testListener }{testOther{}}); err == nil {
+		t.Fatal("Marshal of unregistered variant returned no error")
+	}
+}
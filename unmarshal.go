@@ -7,23 +7,17 @@ import (
 
 // Unmarshal parses the data into the value pointed to by v.
 // v must be a non-nil pointer.
-func Unmarshal(data []byte, v any) error {
-	rv := reflect.ValueOf(v)
-
-	if rv.Kind() != reflect.Pointer || rv.IsNil() {
-		return fmt.Errorf("zon: v must be a non-nil pointer")
-	}
-
-	return safeParseValue(&parser{data: data}, rv)
+func Unmarshal(data []byte, v any, opts ...DecodeOption) error {
+	return unmarshalOptions(opts).Unmarshal(data, v)
 }
 
-// safeParseValue executes parseValue and converts any panic into an error.
+// safeParseValue executes parseDocument and converts any panic into an error.
 func safeParseValue(p *parser, rv reflect.Value) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
-			err = fmt.Errorf("zon: panic during unmarshal: %v", rv)
+			err = fmt.Errorf("zon: panic during unmarshal: %v", r)
 		}
 	}()
 
-	return p.parseValue(rv)
+	return p.parseDocument(rv)
 }
@@ -1,7 +1,11 @@
 package zon
 
 import (
+	"errors"
+	"math"
+	"math/big"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -35,3 +39,593 @@ func TestUnmarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmarshalMultilineString(t *testing.T) {
+	data := []byte(".{\n    .script =\n        \\\\#!/bin/sh\n        \\\\echo \"hi\"\n    ,\n    .other = \\\\x\n    ,\n}\n")
+
+	var v map[string]any
+
+	if err := Unmarshal(data, &v); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	want := map[string]any{"script": "#!/bin/sh\necho \"hi\"", "other": "x"}
+
+	if !reflect.DeepEqual(v, want) {
+		t.Fatalf("v = %#v, want %#v", v, want)
+	}
+}
+
+func TestUnmarshalCharLiteral(t *testing.T) {
+	var v struct {
+		R rune    `zon:"r"`
+		B byte    `zon:"b"`
+		U uint32  `zon:"u"`
+		I int64   `zon:"i"`
+		A any     `zon:"a"`
+		S []int16 `zon:"s"`
+	}
+
+	data := `.{ .r = '\u{1F600}', .b = 'z', .u = '\n', .i = '\'', .a = 'a', .s = .{ 'x', '\xff' } }`
+
+	if err := Unmarshal([]byte(data), &v); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if v.R != '😀' || v.B != 'z' || v.U != '\n' || v.I != '\'' || v.A != int64('a') || !reflect.DeepEqual(v.S, []int16{'x', 0xff}) {
+		t.Fatalf("unexpected result: %+v", v)
+	}
+
+	for _, tt := range []struct {
+		data string
+		v    any
+	}{
+		{`'😀'`, new(uint8)},
+		{`'\xff'`, new(int8)},
+		{`'é'`, new(int8)},
+	} {
+		if err := Unmarshal([]byte(tt.data), tt.v); err == nil {
+			t.Errorf("Unmarshal(%s) into %T returned no error", tt.data, tt.v)
+		}
+	}
+}
+
+func TestUnmarshalIntegerLiterals(t *testing.T) {
+	var v struct {
+		Fingerprint uint64 `zon:"fingerprint"`
+		Mask        uint8  `zon:"mask"`
+		Mode        uint16 `zon:"mode"`
+		Big         int64  `zon:"big"`
+		Min         int64  `zon:"min"`
+		Small       int8   `zon:"small"`
+		Uint        uint   `zon:"uint"`
+	}
+
+	data := `.{
+		.fingerprint = 0x99e5365e8f803dab,
+		.mask = 0b1111_0000,
+		.mode = 0o755,
+		.big = 1_000_000_000,
+		.min = -0x8000_0000_0000_0000,
+		.small = -128,
+		.uint = 0xFF,
+	}`
+
+	if err := Unmarshal([]byte(data), &v); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if v.Fingerprint != 0x99e5365e8f803dab || v.Mask != 0xf0 || v.Mode != 0o755 ||
+		v.Big != 1e9 || v.Min != -1<<63 || v.Small != -128 || v.Uint != 255 {
+		t.Fatalf("unexpected result: %+v", v)
+	}
+}
+
+func TestUnmarshalIntegerErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		data string
+		v    any
+		want string
+	}{
+		{"overflow names field", `.{ .port = 70000 }`, &struct {
+			Port uint16 `zon:"port"`
+		}{}, `integer literal 70000 overflows uint16 in .port at line 1, column 12`},
+		{"negative uint", `.{ .n = -1 }`, &map[string]uint{}, `integer literal -1 overflows uint in .n`},
+		{"int8 overflow", `0x80`, new(int8), "integer literal 0x80 overflows int8 at line 1, column 1"},
+		{"leading zero", `007`, new(int), "leading zero at line 1, column 1"},
+		{"float into int", `1.5`, new(int), "cannot unmarshal number 1.5 into Go value of type int"},
+		{"too large for int64", `.{ .n = 0x1_0000_0000_0000_0000 }`, &map[string]int64{}, `overflows int64 in .n`},
+		{"overflow in slice", `.{ .L = .{ 256 } }`, &struct{ L []uint8 }{}, `integer literal 256 overflows uint8 in .L[0] at line 1, column 12`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Unmarshal([]byte(tt.data), tt.v)
+			if err == nil {
+				t.Fatalf("Unmarshal(%s) returned no error", tt.data)
+			}
+
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %q, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalDynamicIntegers(t *testing.T) {
+	var v map[string]any
+
+	data := `.{ .hex = 0xff, .bin = -0b101, .big = 0x99e5365e8f803dab, .dec = 1_000 }`
+
+	if err := Unmarshal([]byte(data), &v); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	want := map[string]any{
+		"hex": int64(255),
+		"bin": int64(-5),
+		"big": uint64(0x99e5365e8f803dab),
+		"dec": int64(1000),
+	}
+
+	if !reflect.DeepEqual(v, want) {
+		t.Fatalf("v = %#v, want %#v", v, want)
+	}
+}
+
+func TestUnmarshalBigInt(t *testing.T) {
+	var v struct {
+		Hash  *big.Int `zon:"hash"`
+		Value big.Int  `zon:"value"`
+		Nil   *big.Int `zon:"nil"`
+		Any   any      `zon:"any"`
+	}
+
+	data := `.{
+		.hash = 0xffff_ffff_ffff_ffff_ffff_ffff_ffff_ffff,
+		.value = -170141183460469231731687303715884105728,
+		.nil = null,
+		.any = 0x1_0000_0000_0000_0000,
+	}`
+
+	if err := Unmarshal([]byte(data), &v); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	maxU128, _ := new(big.Int).SetString("340282366920938463463374607431768211455", 10)
+	minI128, _ := new(big.Int).SetString("-170141183460469231731687303715884105728", 10)
+	anyWant, _ := new(big.Int).SetString("18446744073709551616", 10)
+
+	if v.Hash.Cmp(maxU128) != 0 || v.Value.Cmp(minI128) != 0 || v.Nil != nil {
+		t.Fatalf("unexpected result: %+v", v)
+	}
+
+	if n, ok := v.Any.(*big.Int); !ok || n.Cmp(anyWant) != 0 {
+		t.Fatalf("v.Any = %#v, want *big.Int %s", v.Any, anyWant)
+	}
+}
+
+func TestUnmarshalFloatLiterals(t *testing.T) {
+	var v struct {
+		Hex    float64   `zon:"hex"`
+		F32    float32   `zon:"f32"`
+		Inf    float64   `zon:"inf"`
+		NegInf float32   `zon:"neg_inf"`
+		NaN    float64   `zon:"nan"`
+		Sep    float64   `zon:"sep"`
+		F128   big.Float `zon:"f128"`
+		Any    []any     `zon:"any"`
+	}
+
+	data := `.{
+		.hex = 0x1.8p3,
+		.f32 = 3.4028235e38,
+		.inf = inf,
+		.neg_inf = -inf,
+		.nan = nan,
+		.sep = 1_000.000_1,
+		.f128 = 1.000000000000000000000000000001,
+		.any = .{ 1.5, -inf, nan, 0x1p-1 },
+	}`
+
+	if err := Unmarshal([]byte(data), &v); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if v.Hex != 12 || v.F32 != math.MaxFloat32 || !math.IsInf(v.Inf, 1) ||
+		!math.IsInf(float64(v.NegInf), -1) || !math.IsNaN(v.NaN) || v.Sep != 1000.0001 {
+		t.Fatalf("unexpected result: %+v", v)
+	}
+
+	if v.F128.Prec() != 113 || v.F128.Cmp(big.NewFloat(1)) <= 0 {
+		t.Fatalf("v.F128 = %s (prec %d)", v.F128.Text('g', -1), v.F128.Prec())
+	}
+
+	if len(v.Any) != 4 || v.Any[0] != 1.5 || !math.IsInf(v.Any[1].(float64), -1) ||
+		!math.IsNaN(v.Any[2].(float64)) || v.Any[3] != 0.5 {
+		t.Fatalf("v.Any = %#v", v.Any)
+	}
+
+	if err := Unmarshal([]byte("3.5e38"), new(float32)); err == nil {
+		t.Fatal("Unmarshal of out of range float32 returned no error")
+	}
+}
+
+func TestUnmarshalStrict(t *testing.T) {
+	type Config struct {
+		Name  string   `zon:"name"`
+		Paths []string `zon:"paths"`
+		Hash  *string  `zon:"hash"`
+		Extra any      `zon:"extra"`
+	}
+
+	for _, tt := range []struct {
+		name    string
+		data    string
+		err     error
+		want    string
+		lenient bool // accepted without Strict
+	}{
+		{"valid", ".{\n    .name = \"zon\",\n    .paths = .{ \"a\", \"b\" },\n    .hash = null,\n    .extra = .{ .a = .{ 1, 2 } },\n}\n// done\n", nil, "", true},
+		{"empty", `.{}`, nil, "", true},
+		{"stray comma", `.{ .paths = .{ "a",, "b" } }`, ErrUnexpectedToken, "expected value or '}', found ',' at line 1, column 20", true},
+		{"leading comma", `.{ , .name = "zon" }`, ErrUnexpectedToken, "expected value or '}', found ','", true},
+		{"missing comma", `.{ .name = "zon" .paths = .{} }`, ErrUnexpectedToken, "expected ',' or '}', found enum literal .paths at line 1, column 18", true},
+		{"missing comma in tuple", `.{ .paths = .{ "a" "b" } }`, ErrUnexpectedToken, "expected ',' or '}', found string \"b\"", true},
+		{"duplicate field", `.{ .name = "a", .name = "b" }`, ErrDuplicateField, "duplicate field .name at line 1, column 17", true},
+		{"duplicate dynamic field", `.{ .extra = .{ .a = 1, .@"a" = 2 } }`, ErrDuplicateField, `duplicate field .@"a"`, true},
+		{"field in tuple", `.{ .paths = .{ "a", .b = "c" } }`, ErrUnexpectedToken, "field .b in tuple at line 1, column 21", false},
+		{"field in dynamic tuple", `.{ .extra = .{ 1, .b = 2 } }`, ErrUnexpectedToken, "field .b in tuple", false},
+		{"element in struct", `.{ .name = "a", "b" }`, ErrUnexpectedToken, "expected field name, found string \"b\"", false},
+		{"trailing data", `.{ .name = "a" } .{}`, ErrUnexpectedToken, "expected end of input, found '.{' at line 1, column 18", true},
+		{"bare identifier", `.{ .extra = foo }`, ErrUnexpectedToken, "expected value, found identifier foo", true},
+		{"null for string", `.{ .name = null }`, nil, "cannot unmarshal null into Go struct field Config.Name of type string at .name, line 1, column 12", true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var c Config
+
+			if tt.want == "" {
+				if err := Unmarshal([]byte(tt.data), &c, Strict(true)); err != nil {
+					t.Fatalf("Unmarshal returned error: %v", err)
+				}
+
+				return
+			}
+
+			err := Unmarshal([]byte(tt.data), &c, Strict(true))
+			if err == nil {
+				t.Fatalf("Unmarshal(%s) returned no error", tt.data)
+			}
+
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %q, want it to contain %q", err, tt.want)
+			}
+
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Fatalf("errors.Is(%v, %v) = false", err, tt.err)
+			}
+
+			if tt.lenient {
+				if err := Unmarshal([]byte(tt.data), &c); err != nil {
+					t.Fatalf("Unmarshal without Strict returned error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestUnmarshalDisallowUnknownFields(t *testing.T) {
+	type Dependency struct {
+		URL  string `zon:"url"`
+		Hash string `zon:"hash"`
+	}
+
+	type Manifest struct {
+		Name              string                `zon:"name"`
+		MinimumZigVersion string                `zon:"minimum_zig_version"`
+		Dependencies      map[string]Dependency `zon:"dependencies"`
+	}
+
+	data := `.{
+    .name = "zon",
+    .minimim_zig_version = "0.14.0",
+    .dependencies = .{
+        .foo = .{ .url = "u", .hsah = "h", .extra = .{ 1, 2 } },
+    },
+}`
+
+	var m Manifest
+
+	if err := Unmarshal([]byte(data), &m); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	err := Unmarshal([]byte(data), &m, DisallowUnknownFields(true))
+
+	var ufe *UnknownFieldsError
+	if !errors.As(err, &ufe) {
+		t.Fatalf("err = %v (%T), want *UnknownFieldsError", err, err)
+	}
+
+	if !errors.Is(err, ErrUnknownField) {
+		t.Fatalf("errors.Is(%v, ErrUnknownField) = false", err)
+	}
+
+	want := []UnknownField{
+		{Path: ".minimim_zig_version", Struct: "Manifest", Suggestion: "minimum_zig_version", Offset: 26, Line: 3, Column: 5},
+		{Path: ".dependencies.foo.hsah", Struct: "Dependency", Suggestion: "hash", Offset: 112, Line: 5, Column: 31},
+		{Path: ".dependencies.foo.extra", Struct: "Dependency", Offset: 125, Line: 5, Column: 44},
+	}
+
+	if !reflect.DeepEqual(ufe.Fields, want) {
+		t.Fatalf("Fields = %+v, want %+v", ufe.Fields, want)
+	}
+
+	if m.Dependencies["foo"].URL != "u" {
+		t.Fatalf("known fields were not decoded: %+v", m)
+	}
+}
+
+func TestUnmarshalUnknownFieldErrors(t *testing.T) {
+	var v struct {
+		Name string `zon:"name"`
+	}
+
+	err := Unmarshal([]byte(`.{ .other = .{ 1, "unterminated }, .name = "a" }`), &v)
+	if !errors.Is(err, ErrInvalidString) {
+		t.Fatalf("err = %v, want ErrInvalidString", err)
+	}
+}
+
+func TestUnmarshalUseNumber(t *testing.T) {
+	var v map[string]any
+
+	data := `.{ .a = 0xff, .b = -1.5e3, .c = 123456789012345678901234567890, .d = -inf }`
+
+	if err := Unmarshal([]byte(data), &v, UseNumber(true)); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	want := map[string]any{
+		"a": Number("0xff"),
+		"b": Number("-1.5e3"),
+		"c": Number("123456789012345678901234567890"),
+		"d": Number("-inf"),
+	}
+
+	if !reflect.DeepEqual(v, want) {
+		t.Fatalf("v = %#v, want %#v", v, want)
+	}
+
+	var s struct {
+		N Number `zon:"n"`
+		I Number `zon:"i"`
+	}
+
+	if err := Unmarshal([]byte(`.{ .n = 1_000, .i = nan }`), &s); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if s.N != "1_000" || s.I != "nan" {
+		t.Fatalf("s = %+v", s)
+	}
+
+	if err := Unmarshal([]byte(`.{ .n = foo }`), &s); !errors.Is(err, ErrInvalidNumber) {
+		t.Fatalf("err = %v, want ErrInvalidNumber", err)
+	}
+}
+
+func TestUnmarshalMapType(t *testing.T) {
+	type Object map[string]any
+
+	var v any
+
+	if err := Unmarshal([]byte(`.{ .a = .{ .b = 1 }, .c = .{ 2 } }`), &v, MapType(reflect.TypeFor[Object]())); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	want := Object{"a": Object{"b": int64(1)}, "c": []any{int64(2)}}
+
+	if !reflect.DeepEqual(v, want) {
+		t.Fatalf("v = %#v, want %#v", v, want)
+	}
+
+	if err := Unmarshal([]byte(`.{}`), &v, MapType(reflect.TypeFor[map[string]int]())); err == nil {
+		t.Fatal("Unmarshal with map[string]int MapType returned no error")
+	}
+}
+
+func TestUnmarshalLimits(t *testing.T) {
+	var v any
+
+	data := []byte(`.{ .a = .{ .b = .{ 1 } } }`)
+
+	if err := Unmarshal(data, &v, MaxDepth(3)); err != nil {
+		t.Fatalf("Unmarshal with MaxDepth(3) returned error: %v", err)
+	}
+
+	err := Unmarshal(data, &v, MaxDepth(2))
+	if !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("err = %v, want ErrLimitExceeded", err)
+	}
+
+	if want := "nesting exceeds the maximum depth of 2 at line 1, column 17"; !strings.Contains(err.Error(), want) {
+		t.Fatalf("err = %q, want it to contain %q", err, want)
+	}
+
+	if err := Unmarshal(data, &v, MaxBytes(len(data))); err != nil {
+		t.Fatalf("Unmarshal with MaxBytes returned error: %v", err)
+	}
+
+	if err := Unmarshal(data, &v, MaxBytes(len(data)-1)); !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("err = %v, want ErrLimitExceeded", err)
+	}
+}
+
+func TestUnmarshalCaseInsensitiveFields(t *testing.T) {
+	var v struct {
+		Name    string
+		Version string `zon:"version"`
+		NAME    string
+	}
+
+	data := []byte(`.{ .name = "a", .VERSION = "b", .NAME = "c" }`)
+
+	if err := Unmarshal(data, &v, CaseInsensitiveFields(true)); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if v.Name != "a" || v.Version != "b" || v.NAME != "c" {
+		t.Fatalf("v = %+v", v)
+	}
+}
+
+func TestUnmarshalOptions(t *testing.T) {
+	var v struct {
+		Name string `zon:"name"`
+	}
+
+	o := UnmarshalOptions{Strict: true, DisallowUnknownFields: true}
+
+	if err := o.Unmarshal([]byte(`.{ .name = "zon", }`), &v); err != nil || v.Name != "zon" {
+		t.Fatalf("Unmarshal = %v, v = %+v", err, v)
+	}
+
+	if err := o.Unmarshal([]byte(`.{ .nmae = "zon" }`), &v); !errors.Is(err, ErrUnknownField) {
+		t.Fatalf("err = %v, want ErrUnknownField", err)
+	}
+
+	if err := o.Unmarshal([]byte(`.{ .name = "zon" } 1`), &v); !errors.Is(err, ErrUnexpectedToken) {
+		t.Fatalf("err = %v, want ErrUnexpectedToken", err)
+	}
+}
+
+func TestUnmarshalTagOptions(t *testing.T) {
+	type T struct {
+		Name    string  `zon:"name"`
+		Omit    []int   `zon:"omit,omitempty"`
+		Skip    string  `zon:"-"`
+		Port    uint16  `zon:"port,string"`
+		Enabled bool    `zon:"enabled,string"`
+		Ratio   float64 `zon:"ratio,string"`
+		Plain   int     `zon:"plain,string"`
+	}
+
+	var v T
+
+	data := `.{ .name = "zon", .omit = .{ 1, 2 }, .Skip = "x", .@"-" = "y", .port = "0x1f90", .enabled = "true", .ratio = "0.5", .plain = 7 }`
+
+	if err := Unmarshal([]byte(data), &v); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	want := T{Name: "zon", Omit: []int{1, 2}, Port: 8080, Enabled: true, Ratio: 0.5, Plain: 7}
+
+	if !reflect.DeepEqual(v, want) {
+		t.Fatalf("v = %+v, want %+v", v, want)
+	}
+
+	if err := Unmarshal([]byte(`.{ .port = "70000" }`), &v); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("err = %v, want ErrOverflow", err)
+	}
+
+	err := Unmarshal([]byte(`.{ .enabled = "yes" }`), &v)
+	if want := `string "yes" is not a valid bool at line 1, column 15`; err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("err = %v, want it to contain %q", err, want)
+	}
+
+	if !errors.Is(err, ErrInvalidString) {
+		t.Fatalf("err = %v, want ErrInvalidString", err)
+	}
+}
+
+func TestUnmarshalDefaults(t *testing.T) {
+	type Server struct {
+		Host string   `zon:"host,default=\"localhost\""`
+		Port uint16   `zon:"port,default=8080"`
+		Tags []string `zon:"tags,default=.{ \"a\", \"b\" }"`
+	}
+
+	type Config struct {
+		Name    string   `zon:"name,default=\"zon\""`
+		Server  Server   `zon:"server"`
+		Mirrors []Server `zon:"mirrors"`
+		Backup  Server   `zon:"backup"`
+	}
+
+	var c Config
+
+	data := `.{ .server = .{ .port = 9000 }, .mirrors = .{ .{ .host = "a" }, .{} } }`
+
+	if err := Unmarshal([]byte(data), &c); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	def := Server{Host: "localhost", Port: 8080, Tags: []string{"a", "b"}}
+
+	want := Config{
+		Name:    "zon",
+		Server:  Server{Host: "localhost", Port: 9000, Tags: []string{"a", "b"}},
+		Mirrors: []Server{{Host: "a", Port: 8080, Tags: []string{"a", "b"}}, def},
+		Backup:  def,
+	}
+
+	if !reflect.DeepEqual(c, want) {
+		t.Fatalf("c = %+v, want %+v", c, want)
+	}
+
+	var bad struct {
+		Port uint8 `zon:"port,default=300"`
+	}
+
+	if err := Unmarshal([]byte(`.{}`), &bad); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("err = %v, want ErrOverflow", err)
+	}
+}
+
+func TestUnmarshalRequired(t *testing.T) {
+	type Dependency struct {
+		URL  string `zon:"url,required"`
+		Hash string `zon:"hash,required"`
+		Lazy bool   `zon:"lazy"`
+	}
+
+	type Manifest struct {
+		Name         string                `zon:"name,required"`
+		Version      string                `zon:"version,required"`
+		Dependencies map[string]Dependency `zon:"dependencies"`
+	}
+
+	var m Manifest
+
+	data := `.{
+    .name = "zon",
+    .dependencies = .{
+        .foo = .{ .url = "u" },
+    },
+}`
+
+	err := Unmarshal([]byte(data), &m)
+
+	var mfe *MissingFieldsError
+	if !errors.As(err, &mfe) {
+		t.Fatalf("err = %v (%T), want *MissingFieldsError", err, err)
+	}
+
+	if !errors.Is(err, ErrMissingField) {
+		t.Fatalf("errors.Is(%v, ErrMissingField) = false", err)
+	}
+
+	want := []MissingField{
+		{Name: "hash", Path: ".dependencies.foo", Struct: "Dependency", Offset: 60, Line: 4, Column: 16},
+		{Name: "version", Struct: "Manifest", Offset: 0, Line: 1, Column: 1},
+	}
+
+	if !reflect.DeepEqual(mfe.Fields, want) {
+		t.Fatalf("Fields = %+v, want %+v", mfe.Fields, want)
+	}
+
+	err = Unmarshal([]byte(`.{ .name = "zon", .versoin = "1" }`), &m, DisallowUnknownFields(true))
+	if !errors.Is(err, ErrMissingField) || !errors.Is(err, ErrUnknownField) {
+		t.Fatalf("err = %v, want ErrMissingField and ErrUnknownField", err)
+	}
+}
@@ -8,7 +8,7 @@
 //   - Marshal, Unmarshal, Encode and Decode functions.
 //   - Encoder and Decoder types.
 //   - Support for struct field tags via `zon:"name"` to customize serialized field names.
-//   - Map keys are automatically prefixed with a dot (`.`) unless already present.
+//   - Map keys are written as field names, quoted as `.@"..."` unless they are identifiers.
 //   - Pointers and interface values are handled transparently, with `nil` encoded as `null`.
 //   - Graceful handling of unknown fields during struct deserialization.
 //   - A simple syntax that uses `.`-prefixed field keys and `=` as a key-value separator.